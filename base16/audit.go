@@ -0,0 +1,146 @@
+package base16
+
+import (
+	"math"
+)
+
+const (
+	// AuditText is the role of the default foreground (base05) on the
+	// backgrounds base00, base01 and base02.
+	AuditText = "text"
+
+	// AuditStatus is the role of the dark foreground (base04) used for status
+	// bars on base01.
+	AuditStatus = "status"
+
+	// AuditComment is the role of comments (base03) on base00.
+	AuditComment = "comment"
+
+	// AuditAccent is the role of the accents (base08 - base0F) on base00 and
+	// base01.
+	AuditAccent = "accent"
+)
+
+// AuditPair defines a foreground/background pair of color names and the
+// minimum contrast required for the pair.
+type AuditPair struct {
+	Role       string
+	Foreground string
+	Background string
+
+	// MinContrast is the minimum WCAG 2.x contrast ratio (see ContrastRatio).
+	MinContrast float64
+
+	// MinAPCA is the minimum absolute APCA lightness contrast (see
+	// APCAContrast).
+	MinAPCA float64
+}
+
+// DefaultAuditPairs returns the pairs checked by Audit by default. Text,
+// status bar text and accents require the WCAG AA level for normal text (4.5)
+// and the APCA level for content text (60). Comments are secondary text and
+// require the WCAG AA level for large text (3) and the APCA level for large
+// text (45).
+func DefaultAuditPairs() []AuditPair {
+	pairs := []AuditPair{
+		{AuditText, "base05", "base00", 4.5, 60},
+		{AuditText, "base05", "base01", 4.5, 60},
+		{AuditText, "base05", "base02", 4.5, 60},
+		{AuditStatus, "base04", "base01", 4.5, 60},
+		{AuditComment, "base03", "base00", 3, 45},
+	}
+	for _, background := range []string{"base00", "base01"} {
+		for i := 8; i < Base16DefaultColors; i++ {
+			pairs = append(pairs, AuditPair{AuditAccent, ColorIndexName(i), background, 4.5, 60})
+		}
+	}
+	return pairs
+}
+
+// AuditResult is the result of checking a single AuditPair.
+type AuditResult struct {
+	AuditPair
+
+	// Contrast is the WCAG 2.x contrast ratio of the pair.
+	Contrast float64
+
+	// APCA is the APCA lightness contrast of the foreground on the background.
+	APCA float64
+
+	// PassContrast and PassAPCA report whether the pair reaches MinContrast
+	// and MinAPCA.
+	PassContrast bool
+	PassAPCA     bool
+
+	// Undefined reports whether the foreground or the background is not
+	// defined. Undefined pairs fail both checks.
+	Undefined bool
+}
+
+// Pass returns true if the pair passes both the WCAG and the APCA check.
+func (r AuditResult) Pass() bool {
+	return r.PassContrast && r.PassAPCA
+}
+
+// AuditReport holds the results of Audit.
+type AuditReport struct {
+	// Results contains one result for each pair in the order of the pairs.
+	Results []AuditResult
+
+	// Score is the percentage of passed checks (WCAG and APCA are counted
+	// separately) in the range [0, 100]. Pairs with undefined colors count as
+	// failed. The score is 100 if no pair was checked.
+	Score float64
+}
+
+// Pass returns true if all checked pairs pass.
+func (r *AuditReport) Pass() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns all results failing the WCAG or the APCA check.
+func (r *AuditReport) Failures() []AuditResult {
+	var failures []AuditResult
+	for _, result := range r.Results {
+		if !result.Pass() {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Audit checks the readability of scheme by evaluating the contrast of
+// foreground/background pairs against WCAG 2.x and APCA thresholds. Pairs
+// with undefined colors fail (see AuditResult.Undefined). The optional
+// arguments replace the default pairs (see DefaultAuditPairs).
+func Audit(scheme Scheme, pairs ...AuditPair) *AuditReport {
+	if len(pairs) == 0 {
+		pairs = DefaultAuditPairs()
+	}
+	report := &AuditReport{Score: 100}
+	passed := 0
+	for _, pair := range pairs {
+		fg, bg := scheme.GetColor(pair.Foreground), scheme.GetColor(pair.Background)
+		if fg == NoColor || bg == NoColor {
+			report.Results = append(report.Results, AuditResult{AuditPair: pair, Undefined: true})
+			continue
+		}
+		result := AuditResult{
+			AuditPair: pair,
+			Contrast:  ContrastRatio(fg, bg),
+			APCA:      APCAContrast(fg, bg),
+		}
+		result.PassContrast = result.Contrast >= pair.MinContrast
+		result.PassAPCA = math.Abs(result.APCA) >= pair.MinAPCA
+		for _, pass := range []bool{result.PassContrast, result.PassAPCA} {
+			if pass {
+				passed++
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	if len(report.Results) > 0 {
+		report.Score = 100 * float64(passed) / float64(2*len(report.Results))
+	}
+	return report
+}
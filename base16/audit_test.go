@@ -0,0 +1,69 @@
+// +build !integration
+
+package base16
+
+import (
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	report := Audit(newTomorrowNight())
+	if len(report.Results) != len(DefaultAuditPairs()) {
+		t.Fatalf("expected value=%v, got=%v", len(DefaultAuditPairs()), len(report.Results))
+	}
+	for _, result := range report.Results {
+		if result.Role == AuditText && !result.Pass() {
+			t.Errorf("expected %s on %s to pass, got contrast=%f apca=%f", result.Foreground, result.Background, result.Contrast, result.APCA)
+		}
+	}
+	// comments of Tomorrow Night (969896 on 1d1f21) pass as large text only
+	failures := report.Failures()
+	for _, failure := range failures {
+		if failure.Role == AuditText {
+			t.Errorf("unexpected failure %+v", failure)
+		}
+	}
+	if report.Pass() != (len(failures) == 0) {
+		t.Errorf("expected Pass to match failures")
+	}
+	if report.Score <= 50 || report.Score > 100 {
+		t.Errorf("unexpected score=%f", report.Score)
+	}
+}
+
+func TestAuditFailing(t *testing.T) {
+	scheme := newTestScheme("gray", "", "777777")
+	report := Audit(scheme)
+	if report.Pass() || report.Score != 0 {
+		t.Errorf("expected all checks to fail, got score=%f", report.Score)
+	}
+	if len(report.Failures()) != len(report.Results) {
+		t.Errorf("expected value=%v, got=%v", len(report.Results), len(report.Failures()))
+	}
+}
+
+func TestAuditCustomPairs(t *testing.T) {
+	scheme, _ := NewScheme("partial", "")
+	scheme.SetColor("base00", NewColor("ffffff"))
+	scheme.SetColor("base05", NewColor("000000"))
+	report := Audit(scheme, AuditPair{Role: AuditText, Foreground: "base05", Background: "base00", MinContrast: 7, MinAPCA: 90},
+		AuditPair{Role: AuditText, Foreground: "base05", Background: "base01", MinContrast: 7, MinAPCA: 90})
+	// pairs with undefined colors fail
+	if len(report.Results) != 2 || report.Pass() || report.Score != 50 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if failures := report.Failures(); len(failures) != 1 || !failures[0].Undefined || failures[0].Background != "base01" {
+		t.Errorf("unexpected failures %+v", failures)
+	}
+}
+
+func TestAuditEmptyScheme(t *testing.T) {
+	scheme, _ := NewScheme("empty", "")
+	report := Audit(scheme)
+	if report.Pass() || report.Score != 0 {
+		t.Errorf("expected all checks to fail, got score=%f", report.Score)
+	}
+	if len(report.Failures()) != len(DefaultAuditPairs()) {
+		t.Errorf("expected value=%v, got=%v", len(DefaultAuditPairs()), len(report.Failures()))
+	}
+}
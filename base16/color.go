@@ -2,10 +2,9 @@ package base16
 
 import (
 	"fmt"
-	"regexp"
+	"math"
 	"sort"
 	"strconv"
-	"strings"
 )
 
 // Uses code parts of the following go module
@@ -46,26 +45,150 @@ func NewColor(rrggbb string) Color {
 	return NoColor
 }
 
+// NewColorRGB returns a new color value from its red, green and blue
+// components.
+func NewColorRGB(r, g, b uint8) Color {
+	return Color(int32(r)<<16 | int32(g)<<8 | int32(b))
+}
+
 // ToHexString returns a 6 characters long hex string of the color value
 func (c Color) ToHexString() string {
 	return fmt.Sprintf("%02x%02x%02x", (c>>16)&0xff, (c>>8)&0xff, c&0xff)
 }
 
-// ColorNameIndex returns the index (zero based) of the color name
-func ColorNameIndex(colorname string) int {
-	if strings.HasPrefix(colorname, "base") && len(colorname) == 6 {
-		if v, e := strconv.ParseInt(strings.TrimLeft(colorname, "base"), 16, 8); e == nil {
-			return int(v)
+// RGB returns the red, green and blue components of the color.
+func (c Color) RGB() (r, g, b uint8) {
+	return uint8((c >> 16) & 0xff), uint8((c >> 8) & 0xff), uint8(c & 0xff)
+}
+
+// Luminance returns the relative luminance of the color in the range [0, 1]
+// as defined by WCAG 2.x.
+func (c Color) Luminance() float64 {
+	r, g, b := c.RGB()
+	return 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b)
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio of the colors a and b in
+// the range [1, 21]. The order of the arguments does not matter.
+func ContrastRatio(a Color, b Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// APCAContrast returns the APCA lightness contrast Lc (APCA-W3 0.0.98G) of
+// text on background in the range of about [-108, 106]. The value is positive
+// for dark text on a light background and negative for light text on a dark
+// background, the order of the arguments matters. Common thresholds for the
+// absolute value are 75 for body text, 60 for content text, 45 for large text
+// and 30 for non-text elements.
+func APCAContrast(text Color, background Color) float64 {
+	const (
+		blackThreshold = 0.022
+		blackClamp     = 1.414
+		deltaYMin      = 0.0005
+		scale          = 1.14
+		offset         = 0.027
+		lowClip        = 0.1
+	)
+	screenLuminance := func(c Color) float64 {
+		r, g, b := c.RGB()
+		y := 0.2126729*math.Pow(float64(r)/255, 2.4) +
+			0.7151522*math.Pow(float64(g)/255, 2.4) +
+			0.0721750*math.Pow(float64(b)/255, 2.4)
+		if y < blackThreshold {
+			y += math.Pow(blackThreshold-y, blackClamp)
+		}
+		return y
+	}
+	yText, yBackground := screenLuminance(text), screenLuminance(background)
+	if math.Abs(yBackground-yText) < deltaYMin {
+		return 0
+	}
+
+	if yBackground > yText {
+		// dark text on a light background
+		sapc := (math.Pow(yBackground, 0.56) - math.Pow(yText, 0.57)) * scale
+		if sapc < lowClip {
+			return 0
 		}
+		return (sapc - offset) * 100
+	}
+	// light text on a dark background
+	sapc := (math.Pow(yBackground, 0.65) - math.Pow(yText, 0.62)) * scale
+	if sapc > -lowClip {
+		return 0
+	}
+	return (sapc + offset) * 100
+}
+
+// linearize converts an 8 bit sRGB component to linear light.
+func linearize(v uint8) float64 {
+	s := float64(v) / 255
+	if s <= 0.04045 {
+		return s / 12.92
+	}
+	return math.Pow((s+0.055)/1.055, 2.4)
+}
+
+// colorIndexNames caches the color names of all valid color indexes.
+var colorIndexNames = func() [ExtendedModeMaxColors]string {
+	var names [ExtendedModeMaxColors]string
+	for i := range names {
+		names[i] = fmt.Sprintf("base%02X", i)
+	}
+	return names
+}()
+
+// ColorNameIndex returns the index (zero based) of the color name. The color
+// name is case insensitive (e.g. "base0A", "base0a" or "BASE0A"). Returns -1
+// if colorname is not a color name.
+func ColorNameIndex(colorname string) int {
+	// setting bit 0x20 converts upper case ASCII letters to lower case
+	if len(colorname) != 6 || colorname[0]|0x20 != 'b' || colorname[1]|0x20 != 'a' ||
+		colorname[2]|0x20 != 's' || colorname[3]|0x20 != 'e' {
+		return -1
+	}
+	hi, lo := hexDigit(colorname[4]), hexDigit(colorname[5])
+	if hi < 0 || lo < 0 {
+		return -1
+	}
+	return hi<<4 | lo
+}
+
+// hexDigit returns the value of the hex digit c or -1 if c is not a hex digit.
+func hexDigit(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'A' <= c && c <= 'F':
+		return int(c - 'A' + 10)
+	case 'a' <= c && c <= 'f':
+		return int(c - 'a' + 10)
 	}
 	return -1
 }
 
 // ColorIndexName returns the color name of the index (zero based)
 func ColorIndexName(index int) string {
+	if index >= 0 && index < len(colorIndexNames) {
+		return colorIndexNames[index]
+	}
 	return fmt.Sprintf("base%02X", index)
 }
 
+// canonicalColorName converts colorname to the canonical form of a base16
+// color name (lower case prefix and upper case hex digits, e.g. "base0A").
+// Returns colorname unchanged if it does not look like a color name.
+func canonicalColorName(colorname string) string {
+	if index := ColorNameIndex(colorname); index >= 0 {
+		return ColorIndexName(index)
+	}
+	return colorname
+}
+
 // ColorNames generates a slice of strings with base16 color names.
 func ColorNames(count int) []string {
 	keys := make([]string, 0, count)
@@ -76,13 +199,16 @@ func ColorNames(count int) []string {
 	return keys
 }
 
-// ValidColorName returns true is the color name is a valid base16 color name.
-// The second argument is a flag when using the base16 extended mode.
+// ValidColorName returns true is the color name is a valid base16 color name
+// in canonical form (e.g. "base0A"). The second argument is a flag when using
+// the base16 extended mode.
 func ValidColorName(colorname string, extended ...bool) bool {
-	colorNameRe := `base[0][0-9A-F]`
-	if len(extended) == 1 && extended[0] {
-		colorNameRe = `base[01][0-9A-F]`
+	if len(colorname) != 6 || colorname[:4] != "base" {
+		return false
+	}
+	if colorname[4] != '0' && !(colorname[4] == '1' && len(extended) == 1 && extended[0]) {
+		return false
 	}
-	re := regexp.MustCompile(colorNameRe)
-	return re.MatchString(colorname)
+	c := colorname[5]
+	return ('0' <= c && c <= '9') || ('A' <= c && c <= 'F')
 }
@@ -3,6 +3,7 @@
 package base16
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -186,3 +187,120 @@ func TestValidColorName(t *testing.T) {
 		t.Errorf("expected colorname=%s to be valid (true)", colorname)
 	}
 }
+
+func TestRGB(t *testing.T) {
+	r, g, b := NewColor("f7ca88").RGB()
+	if r != 0xf7 || g != 0xca || b != 0x88 {
+		t.Errorf("expected value=(247, 202, 136), got=(%d, %d, %d)", r, g, b)
+	}
+}
+
+func TestLuminance(t *testing.T) {
+	testCases := []struct {
+		color  Color
+		expect float64
+	}{
+		{NewColor("000000"), 0},
+		{NewColor("ffffff"), 1},
+		{NewColor("ff0000"), 0.2126},
+		{NewColor("808080"), 0.2158605},
+	}
+	for _, table := range testCases {
+		got := table.color.Luminance()
+		if math.Abs(got-table.expect) > 1e-6 {
+			t.Errorf("color=%s: expected value=%f, got=%f", table.color.ToHexString(), table.expect, got)
+		}
+	}
+}
+
+func TestColorNameIndexCaseInsensitive(t *testing.T) {
+	testCases := map[string]int{
+		"base0a": 10,
+		"BASE0A": 10,
+		"base1f": 31,
+		"base+1": -1,
+		"base0":  -1,
+		"foo0A":  -1,
+		"base0G": -1,
+	}
+	for colorname, expectInt := range testCases {
+		if gotInt := ColorNameIndex(colorname); gotInt != expectInt {
+			t.Errorf("colorname=%s: expected value=%d, got=%d", colorname, expectInt, gotInt)
+		}
+	}
+}
+
+func TestValidColorNameInvalid(t *testing.T) {
+	for _, colorname := range []string{"base0a", "xbase0A", "base0A0", "base20", "Base00", "base1G"} {
+		if ValidColorName(colorname, true) {
+			t.Errorf("expected colorname=%s to be invalid", colorname)
+		}
+	}
+	if !ValidColorName("base1F", true) {
+		t.Errorf("expected colorname=%s to be valid", "base1F")
+	}
+}
+
+func TestColorNameAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		ValidColorName("base0A")
+		ValidColorName("base1A", true)
+		ColorNameIndex("base0A")
+		ColorIndexName(10)
+	})
+	if allocs != 0 {
+		t.Errorf("expected value=%d allocations, got=%f", 0, allocs)
+	}
+}
+
+func BenchmarkValidColorName(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidColorName("base0A")
+	}
+}
+
+func BenchmarkColorNameIndex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ColorNameIndex("base0A")
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	testCases := []struct {
+		a, b   Color
+		expect float64
+	}{
+		{NewColor("000000"), NewColor("ffffff"), 21},
+		{NewColor("ffffff"), NewColor("000000"), 21},
+		{NewColor("777777"), NewColor("ffffff"), 4.4784},
+		{NewColor("181818"), NewColor("181818"), 1},
+	}
+	for _, table := range testCases {
+		got := ContrastRatio(table.a, table.b)
+		if math.Abs(got-table.expect) > 1e-3 {
+			t.Errorf("expected value=%f, got=%f", table.expect, got)
+		}
+	}
+}
+
+func TestAPCAContrast(t *testing.T) {
+	testCases := []struct {
+		text       string
+		background string
+		expected   float64
+	}{
+		{"000000", "ffffff", 106.04},
+		{"ffffff", "000000", -107.88},
+		{"888888", "ffffff", 63.06},
+		{"ffffff", "888888", -68.54},
+		{"777777", "777777", 0},
+	}
+	for _, tc := range testCases {
+		lc := APCAContrast(NewColor(tc.text), NewColor(tc.background))
+		if math.Abs(lc-tc.expected) > 0.01 {
+			t.Errorf("%s on %s: expected value=%v, got=%v", tc.text, tc.background, tc.expected, lc)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package base16
+
+import (
+	"fmt"
+)
+
+// Deficiency is a color vision deficiency.
+type Deficiency int
+
+const (
+	// Protanopia is the absence of red sensitive cones.
+	Protanopia Deficiency = iota
+
+	// Deuteranopia is the absence of green sensitive cones.
+	Deuteranopia
+
+	// Tritanopia is the absence of blue sensitive cones.
+	Tritanopia
+
+	// Achromatopsia is the absence of color vision.
+	Achromatopsia
+)
+
+// Deficiencies contains all supported color vision deficiencies.
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia, Achromatopsia}
+
+// String returns the name of the deficiency.
+func (d Deficiency) String() string {
+	switch d {
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	case Achromatopsia:
+		return "achromatopsia"
+	}
+	return fmt.Sprintf("Deficiency(%d)", int(d))
+}
+
+// deficiencyMatrices holds the simulation matrices of Machado, Oliveira and
+// Fernandes (2009) for full severity, applied to linear sRGB.
+var deficiencyMatrices = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+	Achromatopsia: {
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+	},
+}
+
+// Simulate returns the color as perceived with the deficiency d. The optional
+// argument sets the severity in the range [0, 1] (defaults to 1, values out of
+// range are clamped): partial deficiencies are approximated by blending the
+// normal and the fully deficient perception in linear light. NoColor is
+// returned unchanged.
+func (c Color) Simulate(d Deficiency, severity ...float64) Color {
+	m, ok := deficiencyMatrices[d]
+	if c == NoColor || !ok {
+		return c
+	}
+	s := 1.0
+	if len(severity) == 1 {
+		s = severity[0]
+		if s < 0 {
+			s = 0
+		} else if s > 1 {
+			s = 1
+		}
+	}
+	r8, g8, b8 := c.RGB()
+	in := [3]float64{linearize(r8), linearize(g8), linearize(b8)}
+	var out [3]float64
+	for i, row := range m {
+		simulated := row[0]*in[0] + row[1]*in[1] + row[2]*in[2]
+		out[i] = in[i] + (simulated-in[i])*s
+	}
+	return NewColorRGB(delinearize(out[0]), delinearize(out[1]), delinearize(out[2]))
+}
+
+// DefaultMinAccentDistance is the default minimum OKLab distance (see
+// Color.Distance) of two accents to be considered distinguishable.
+const DefaultMinAccentDistance = 0.04
+
+// CVDOptions configures CheckDistinguishability.
+type CVDOptions struct {
+	// Deficiencies are the checked deficiencies (defaults to Deficiencies).
+	Deficiencies []Deficiency
+
+	// Severity is the severity of the deficiencies in the range (0, 1]
+	// (defaults to 1).
+	Severity float64
+
+	// MinDistance is the minimum OKLab distance of two accents (defaults to
+	// DefaultMinAccentDistance).
+	MinDistance float64
+}
+
+// CVDConflict describes two accents which are hard to distinguish with a
+// color vision deficiency.
+type CVDConflict struct {
+	Deficiency Deficiency
+	A          string
+	B          string
+
+	// Distance is the OKLab distance of the simulated colors.
+	Distance float64
+}
+
+// CheckDistinguishability simulates the accents base08 - base0F of scheme for
+// each deficiency and returns all accent pairs closer than the minimum
+// distance. The conflicts are ordered by deficiency and color names. Undefined
+// accents are skipped.
+func CheckDistinguishability(scheme Scheme, options ...CVDOptions) []CVDConflict {
+	var opts CVDOptions
+	if len(options) == 1 {
+		opts = options[0]
+	}
+	if opts.Deficiencies == nil {
+		opts.Deficiencies = Deficiencies
+	}
+	if opts.Severity == 0 {
+		opts.Severity = 1
+	}
+	if opts.MinDistance == 0 {
+		opts.MinDistance = DefaultMinAccentDistance
+	}
+
+	var conflicts []CVDConflict
+	for _, d := range opts.Deficiencies {
+		var simulated [8]Color
+		for i := range simulated {
+			simulated[i] = scheme.ColorAt(8+i).Simulate(d, opts.Severity)
+		}
+		for i := range simulated {
+			for j := i + 1; j < len(simulated); j++ {
+				if simulated[i] == NoColor || simulated[j] == NoColor {
+					continue
+				}
+				if distance := simulated[i].Distance(simulated[j]); distance < opts.MinDistance {
+					conflicts = append(conflicts, CVDConflict{
+						Deficiency: d,
+						A:          ColorIndexName(8 + i),
+						B:          ColorIndexName(8 + j),
+						Distance:   distance,
+					})
+				}
+			}
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,82 @@
+// +build !integration
+
+package base16
+
+import (
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	red := NewColor("ff0000")
+	for _, d := range Deficiencies {
+		if c := red.Simulate(d, 0); c != red {
+			t.Errorf("%s: expected severity 0 to keep the color, got=%s", d, c.ToHexString())
+		}
+		if c := NoColor.Simulate(d); c != NoColor {
+			t.Errorf("%s: expected NoColor, got=%s", d, c.ToHexString())
+		}
+		if c := NewColor("ffffff").Simulate(d); c.Distance(NewColor("ffffff")) > 0.01 {
+			t.Errorf("%s: expected white to stay white, got=%s", d, c.ToHexString())
+		}
+	}
+	if r, g, b := NewColor("3366cc").Simulate(Achromatopsia).RGB(); r != g || g != b {
+		t.Errorf("expected gray, got=%d,%d,%d", r, g, b)
+	}
+
+	// red and green collapse with red-green deficiencies
+	green := NewColor("00aa00")
+	for _, d := range []Deficiency{Protanopia, Deuteranopia} {
+		full := red.Simulate(d).Distance(green.Simulate(d))
+		half := red.Simulate(d, 0.5).Distance(green.Simulate(d, 0.5))
+		if !(full < half && half < red.Distance(green)) {
+			t.Errorf("%s: expected distance to decrease with severity, got full=%f half=%f", d, full, half)
+		}
+	}
+	if Deuteranopia.String() != "deuteranopia" || Deficiency(42).String() != "Deficiency(42)" {
+		t.Errorf("unexpected names %s %s", Deuteranopia, Deficiency(42))
+	}
+}
+
+func TestCheckDistinguishability(t *testing.T) {
+	scheme := newTestScheme("accents", "", "1d1f21")
+	accents := []string{"cc4444", "3333cc", "dddd33", "778822", "33aaaa", "aa33aa", "333333", "777777"}
+	for i, c := range accents {
+		scheme.SetColorAt(8+i, NewColor(c))
+	}
+	conflicts := CheckDistinguishability(scheme, CVDOptions{Deficiencies: []Deficiency{Deuteranopia}})
+	found := false
+	for _, conflict := range conflicts {
+		if conflict.Deficiency != Deuteranopia || conflict.Distance >= DefaultMinAccentDistance {
+			t.Errorf("unexpected conflict %+v", conflict)
+		}
+		// red (base08) and olive green (base0B) collapse with deuteranopia
+		if conflict.A == "base08" && conflict.B == "base0B" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected conflict of base08 and base0B, got %+v", conflicts)
+	}
+
+	if d := scheme.GetColor("base08").Distance(scheme.GetColor("base0B")); d < 0.2 {
+		t.Errorf("expected base08 and base0B to be distinct with normal vision, got distance=%f", d)
+	}
+
+	// identical accents conflict for all deficiencies
+	scheme.SetColor("base0F", NewColor("cc4444"))
+	conflicts = CheckDistinguishability(scheme)
+	count := 0
+	for _, conflict := range conflicts {
+		if conflict.A == "base08" && conflict.B == "base0F" {
+			count++
+		}
+	}
+	if count != len(Deficiencies) {
+		t.Errorf("expected value=%v, got=%v", len(Deficiencies), count)
+	}
+
+	empty, _ := NewScheme("empty", "")
+	if conflicts := CheckDistinguishability(empty); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+}
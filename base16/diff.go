@@ -0,0 +1,104 @@
+package base16
+
+// Metadata field names used by MetadataChange.
+const (
+	FieldScheme  = "scheme"
+	FieldAuthor  = "author"
+	FieldVariant = "variant"
+)
+
+// ColorChange describes a color which differs between two schemes.
+type ColorChange struct {
+	// Name is the color name (e.g. "base0A").
+	Name string
+
+	// Old is the color of the first scheme (NoColor if undefined).
+	Old Color
+
+	// New is the color of the second scheme (NoColor if undefined).
+	New Color
+
+	// Distance is the distance between Old and New (see Color.Distance). If
+	// one of the colors is NoColor, the distance is -1.
+	Distance float64
+}
+
+// MetadataChange describes a metadata field which differs between two
+// schemes.
+type MetadataChange struct {
+	// Field is the name of the field (see FieldScheme, FieldAuthor and
+	// FieldVariant).
+	Field string
+
+	// Old is the value of the first scheme.
+	Old string
+
+	// New is the value of the second scheme.
+	New string
+}
+
+// SchemeDiff holds the differences between two schemes.
+type SchemeDiff struct {
+	// Colors contains all changed colors sorted by color name.
+	Colors []ColorChange
+
+	// Metadata contains all changed metadata fields.
+	Metadata []MetadataChange
+}
+
+// Empty returns true if the diff does not contain any changes.
+func (d *SchemeDiff) Empty() bool {
+	return len(d.Colors) == 0 && len(d.Metadata) == 0
+}
+
+// Diff returns the changes needed to turn scheme a into scheme b. Colors
+// defined by only one of the schemes (extended mode) are reported with
+// NoColor on the other side.
+func Diff(a Scheme, b Scheme) *SchemeDiff {
+	diff := &SchemeDiff{}
+
+	metadata := []MetadataChange{
+		{Field: FieldScheme, Old: a.Scheme(), New: b.Scheme()},
+		{Field: FieldAuthor, Old: a.Author(), New: b.Author()},
+		{Field: FieldVariant, Old: a.Variant(), New: b.Variant()},
+	}
+	for _, change := range metadata {
+		if change.Old != change.New {
+			diff.Metadata = append(diff.Metadata, change)
+		}
+	}
+
+	for _, colorname := range unionColorNames(a, b) {
+		change := ColorChange{
+			Name:     colorname,
+			Old:      schemeColor(a, colorname),
+			New:      schemeColor(b, colorname),
+			Distance: -1,
+		}
+		if change.Old == change.New {
+			continue
+		}
+		if change.Old != NoColor && change.New != NoColor {
+			change.Distance = change.Old.Distance(change.New)
+		}
+		diff.Colors = append(diff.Colors, change)
+	}
+	return diff
+}
+
+// schemeColor returns the color given by colorname or NoColor if the scheme
+// does not define the color.
+func schemeColor(scheme Scheme, colorname string) Color {
+	return scheme.ColorAt(ColorNameIndex(colorname))
+}
+
+// unionColorNames returns the sorted color names of all given schemes.
+func unionColorNames(schemes ...Scheme) []string {
+	count := 0
+	for _, scheme := range schemes {
+		if scheme.CountColors() > count {
+			count = scheme.CountColors()
+		}
+	}
+	return ColorNames(count)
+}
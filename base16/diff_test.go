@@ -0,0 +1,52 @@
+// +build !integration
+
+package base16
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a, _ := NewScheme("test", "nobody")
+	b, _ := NewScheme("test", "fred", 17)
+	for _, colorname := range a.GetColorNames() {
+		a.SetColor(colorname, NewColor("181818"))
+		b.SetColor(colorname, NewColor("181818"))
+	}
+	b.SetColor("base08", NewColor("ab4642"))
+	b.SetColor("base10", NewColor("ff0000"))
+	b.SetVariant(VariantDark)
+
+	diff := Diff(a, b)
+	if diff.Empty() {
+		t.Fatalf("expected diff not to be empty")
+	}
+
+	expectMetadata := []MetadataChange{
+		{Field: FieldAuthor, Old: "nobody", New: "fred"},
+		{Field: FieldVariant, Old: "", New: "dark"},
+	}
+	if !reflect.DeepEqual(expectMetadata, diff.Metadata) {
+		t.Errorf("expected value=%v, got=%v", expectMetadata, diff.Metadata)
+	}
+
+	if len(diff.Colors) != 2 {
+		t.Fatalf("expected value=%d, got=%d", 2, len(diff.Colors))
+	}
+	change := diff.Colors[0]
+	if change.Name != "base08" || change.Old != NewColor("181818") || change.New != NewColor("ab4642") {
+		t.Errorf("unexpected color change %v", change)
+	}
+	if change.Distance <= 0 {
+		t.Errorf("expected distance > 0, got=%f", change.Distance)
+	}
+	change = diff.Colors[1]
+	if change.Name != "base10" || change.Old != NoColor || change.Distance != -1 {
+		t.Errorf("unexpected color change %v", change)
+	}
+
+	if !Diff(a, Clone(a)).Empty() {
+		t.Errorf("expected diff of clone to be empty")
+	}
+}
@@ -4,17 +4,15 @@ package base16
 
 import (
 	"fmt"
-	"testing"
 )
 
-func ExampleBase16(t *testing.T) {
-	var scheme Scheme
-	var err error
-
-	scheme, err = NewScheme("test", "nobody")
+func ExampleNewScheme() {
+	scheme, err := NewScheme("test", "nobody")
 	if err != nil {
-		t.Errorf("expected no error, got %v", err)
+		fmt.Println(err)
+		return
 	}
 
 	fmt.Printf("scheme author: %s", scheme.Author())
+	// Output: scheme author: nobody
 }
@@ -0,0 +1,335 @@
+package base16
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultPaletteSize is the default number of colors extracted from an
+	// image.
+	DefaultPaletteSize = 16
+
+	// DefaultMaxSamples is the default maximum number of pixels sampled from an
+	// image.
+	DefaultMaxSamples = 10000
+)
+
+// ExtractOptions configures FromImage.
+type ExtractOptions struct {
+	// Scheme is the name of the generated scheme (defaults to "Extracted").
+	Scheme string
+
+	// Author is the author of the generated scheme.
+	Author string
+
+	// Variant is either VariantDark or VariantLight. Defaults to the variant
+	// matching the average lightness of the image.
+	Variant string
+
+	// Contrast is the minimum WCAG contrast ratio of the default foreground
+	// (base05) and the accents (base08 - base0F) against the background
+	// (defaults to DefaultContrast).
+	Contrast float64
+
+	// PaletteSize is the number of colors extracted from the image (defaults
+	// to DefaultPaletteSize).
+	PaletteSize int
+
+	// MaxSamples is the maximum number of pixels sampled from the image
+	// (defaults to DefaultMaxSamples).
+	MaxSamples int
+}
+
+// PaletteColor is a color extracted from an image. Weight is the share of the
+// sampled pixels represented by the color in the range (0, 1].
+type PaletteColor struct {
+	Color  Color
+	Weight float64
+}
+
+// minAccentChroma is the minimum OKLCh chroma of a palette color to be used as
+// accent, maxAccentHueDelta the maximum hue distance in degrees to the
+// canonical hue of the accent.
+const (
+	minAccentChroma   = 0.04
+	maxAccentHueDelta = 40
+)
+
+// FromImage derives a complete base16 scheme from the colors of img, e.g. to
+// match a wallpaper. The palette is extracted with ExtractPalette. Background
+// and foreground are the darkest and lightest palette colors (swapped for the
+// light variant), pushed towards the lightness extremes and desaturated to
+// keep the ramp base00 - base07 readable. Each accent base08 - base0F uses the
+// palette color closest to its canonical hue; accents without a matching
+// palette color are harmonized around the most frequent saturated palette
+// color (see Generate). The lightness of base05 and the accents is adjusted to
+// reach the target contrast. Returns an error if the ramp cannot reach the
+// target contrast.
+func FromImage(img image.Image, options ...ExtractOptions) (Scheme, error) {
+	var opts ExtractOptions
+	if len(options) == 1 {
+		opts = options[0]
+	}
+	if opts.Scheme == "" {
+		opts.Scheme = "Extracted"
+	}
+	if opts.Contrast == 0 {
+		opts.Contrast = DefaultContrast
+	}
+	if opts.Contrast < 1 || opts.Contrast > 21 {
+		return nil, fmt.Errorf("contrast must be in the range [1, 21], got %g", opts.Contrast)
+	}
+	if opts.Variant != "" && opts.Variant != VariantDark && opts.Variant != VariantLight {
+		return nil, fmt.Errorf("invalid variant %q", opts.Variant)
+	}
+
+	palette, err := ExtractPalette(img, opts.PaletteSize, opts.MaxSamples)
+	if err != nil {
+		return nil, err
+	}
+	colors := make([]OKLCh, len(palette))
+	lightness := 0.0
+	for i, p := range palette {
+		colors[i] = p.Color.OKLCh()
+		lightness += colors[i].L * p.Weight
+	}
+
+	dark := lightness < 0.5
+	if opts.Variant != "" {
+		dark = opts.Variant == VariantDark
+	}
+	darkest, lightest := colors[0], colors[0]
+	for _, lch := range colors[1:] {
+		if lch.L < darkest.L {
+			darkest = lch
+		}
+		if lch.L > lightest.L {
+			lightest = lch
+		}
+	}
+	bg, fg := darkest, lightest
+	bg.L, fg.L = math.Min(bg.L, 0.25), math.Max(fg.L, 0.85)
+	if !dark {
+		bg, fg = lightest, darkest
+		bg.L, fg.L = math.Max(bg.L, 0.95), math.Min(fg.L, 0.4)
+	}
+	bg.C, fg.C = math.Min(bg.C, 0.04), math.Min(fg.C, 0.03)
+
+	scheme, _ := NewScheme(opts.Scheme, opts.Author)
+	if err := setRamp(scheme, bg, fg, dark, opts.Contrast); err != nil {
+		return nil, err
+	}
+	setAccents(scheme, paletteAccents(colors), opts.Contrast)
+	return scheme, nil
+}
+
+// paletteAccents assigns the palette colors (converted to OKLCh and ordered by
+// descending weight) to the accents base08 - base0F.
+func paletteAccents(colors []OKLCh) [8]OKLCh {
+	// the most frequent saturated color is the seed for accents without a
+	// matching palette color
+	seed := OKLCh{L: 0.7, C: 0.1, H: accentHues[5]}
+	for _, lch := range colors {
+		if lch.C >= minAccentChroma {
+			seed = lch
+			break
+		}
+	}
+	accents := harmonizedAccents(seed)
+	for i := 0; i < 7; i++ {
+		best, bestDelta := -1, float64(maxAccentHueDelta)
+		for j, lch := range colors {
+			if lch.C < minAccentChroma {
+				continue
+			}
+			if delta := math.Abs(hueDelta(lch.H, accentHues[i])); delta < bestDelta {
+				best, bestDelta = j, delta
+			}
+		}
+		if best >= 0 {
+			accents[i] = colors[best]
+		}
+	}
+	// brown is a darker, desaturated orange
+	accents[7] = OKLCh{L: accents[1].L * 0.8, C: accents[1].C * 0.7, H: accents[1].H}
+	return accents
+}
+
+// ExtractPalette returns the dominant colors of img ordered by descending
+// weight. The colors are found by k-means clustering of the sampled pixels in
+// the OKLab color space. count is the maximum number of colors (defaults to
+// DefaultPaletteSize if count <= 0), fewer colors are returned if the image
+// has fewer distinct colors. The optional argument limits the number of
+// sampled pixels (defaults to DefaultMaxSamples). Fully transparent pixels are
+// ignored. Returns an error if the image has no opaque pixels.
+func ExtractPalette(img image.Image, count int, maxSamples ...int) ([]PaletteColor, error) {
+	if count <= 0 {
+		count = DefaultPaletteSize
+	}
+	limit := DefaultMaxSamples
+	if len(maxSamples) == 1 && maxSamples[0] > 0 {
+		limit = maxSamples[0]
+	}
+	if img == nil {
+		return nil, fmt.Errorf("image must not be nil")
+	}
+	samples := samplePixels(img, limit)
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("image has no opaque pixels")
+	}
+
+	centroids, sizes := kmeans(samples, count)
+	palette := make([]PaletteColor, 0, len(centroids))
+	for i, centroid := range centroids {
+		if sizes[i] == 0 {
+			continue
+		}
+		palette = append(palette, PaletteColor{
+			Color:  centroid.Color(),
+			Weight: float64(sizes[i]) / float64(len(samples)),
+		})
+	}
+	sort.SliceStable(palette, func(i, j int) bool {
+		return palette[i].Weight > palette[j].Weight
+	})
+	return palette, nil
+}
+
+// samplePixels returns at most limit pixels of img converted to OKLab. The
+// pixels are sampled on a regular grid.
+func samplePixels(img image.Image, limit int) []OKLab {
+	bounds := img.Bounds()
+	pixels := bounds.Dx() * bounds.Dy()
+	if pixels <= 0 {
+		return nil
+	}
+	stride := 1
+	if pixels > limit {
+		stride = int(math.Ceil(math.Sqrt(float64(pixels) / float64(limit))))
+	}
+
+	samples := make([]OKLab, 0, pixels/(stride*stride)+1)
+	cache := make(map[Color]OKLab)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stride {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stride {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
+			// un-premultiply the 16 bit components
+			c := NewColorRGB(uint8(r*0xffff/a>>8), uint8(g*0xffff/a>>8), uint8(b*0xffff/a>>8))
+			lab, ok := cache[c]
+			if !ok {
+				lab = c.OKLab()
+				cache[c] = lab
+			}
+			samples = append(samples, lab)
+		}
+	}
+	return samples
+}
+
+// kmeans clusters samples into at most k clusters and returns the centroids
+// and the number of samples of each cluster. The initial centroids are chosen
+// deterministically by farthest point traversal.
+func kmeans(samples []OKLab, k int) ([]OKLab, []int) {
+	const maxIterations = 32
+
+	centroids := initCentroids(samples, k)
+	sizes := make([]int, len(centroids))
+	assignment := make([]int, len(samples))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		changed := false
+		for i, s := range samples {
+			nearest := nearestCentroid(centroids, s)
+			if nearest != assignment[i] {
+				assignment[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([]OKLab, len(centroids))
+		for i := range sizes {
+			sizes[i] = 0
+		}
+		for i, s := range samples {
+			c := assignment[i]
+			sums[c].L += s.L
+			sums[c].A += s.A
+			sums[c].B += s.B
+			sizes[c]++
+		}
+		for i, sum := range sums {
+			if sizes[i] > 0 {
+				n := float64(sizes[i])
+				centroids[i] = OKLab{L: sum.L / n, A: sum.A / n, B: sum.B / n}
+			}
+		}
+	}
+	return centroids, sizes
+}
+
+// initCentroids returns up to k initial centroids. The first centroid is the
+// sample closest to the mean, each further centroid is the sample farthest
+// from all centroids chosen so far. Stops early if all samples coincide with a
+// centroid.
+func initCentroids(samples []OKLab, k int) []OKLab {
+	var mean OKLab
+	for _, s := range samples {
+		mean.L += s.L
+		mean.A += s.A
+		mean.B += s.B
+	}
+	n := float64(len(samples))
+	mean = OKLab{L: mean.L / n, A: mean.A / n, B: mean.B / n}
+
+	first := nearestCentroid(samples, mean)
+	centroids := []OKLab{samples[first]}
+	distances := make([]float64, len(samples))
+	for i, s := range samples {
+		distances[i] = labDistance(s, samples[first])
+	}
+	for len(centroids) < k {
+		farthest := 0
+		for i, d := range distances {
+			if d > distances[farthest] {
+				farthest = i
+			}
+		}
+		if distances[farthest] == 0 {
+			break
+		}
+		centroid := samples[farthest]
+		centroids = append(centroids, centroid)
+		for i, s := range samples {
+			distances[i] = math.Min(distances[i], labDistance(s, centroid))
+		}
+	}
+	return centroids
+}
+
+// nearestCentroid returns the index of the centroid closest to lab.
+func nearestCentroid(centroids []OKLab, lab OKLab) int {
+	nearest, nearestDistance := 0, math.Inf(1)
+	for i, c := range centroids {
+		if d := labDistance(c, lab); d < nearestDistance {
+			nearest, nearestDistance = i, d
+		}
+	}
+	return nearest
+}
+
+// labDistance returns the squared euclidean distance between a and b.
+func labDistance(a OKLab, b OKLab) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return dl*dl + da*da + db*db
+}
@@ -0,0 +1,118 @@
+// +build !integration
+
+package base16
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// newTestImage returns a 100x100 image filled with background except for
+// horizontal stripes of the given colors, each 5 pixels high.
+func newTestImage(background color.Color, stripes ...color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		c := background
+		if i := y / 5; i < len(stripes) {
+			c = stripes[i]
+		}
+		for x := 0; x < 100; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestExtractPalette(t *testing.T) {
+	img := newTestImage(color.RGBA{0x1d, 0x1f, 0x21, 0xff}, color.RGBA{0xcc, 0x66, 0x66, 0xff})
+	palette, err := ExtractPalette(img, 8)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(palette) != 2 {
+		t.Fatalf("expected value=%v, got=%v", 2, len(palette))
+	}
+	if palette[0].Color != NewColor("1d1f21") || palette[1].Color != NewColor("cc6666") {
+		t.Errorf("unexpected palette %s %s", palette[0].Color.ToHexString(), palette[1].Color.ToHexString())
+	}
+	if math.Abs(palette[0].Weight-0.95) > 1e-9 || math.Abs(palette[1].Weight-0.05) > 1e-9 {
+		t.Errorf("unexpected weights %f %f", palette[0].Weight, palette[1].Weight)
+	}
+
+	// sampling a subset of the pixels
+	palette, err = ExtractPalette(img, 8, 100)
+	if err != nil || len(palette) != 2 {
+		t.Errorf("expected 2 colors without error, got=%d err=%v", len(palette), err)
+	}
+}
+
+func TestExtractPaletteErrorHandling(t *testing.T) {
+	if _, err := ExtractPalette(nil, 8); err == nil {
+		t.Errorf("expected error for nil image")
+	}
+	if _, err := ExtractPalette(image.NewRGBA(image.Rect(0, 0, 10, 10)), 8); err == nil {
+		t.Errorf("expected error for transparent image")
+	}
+	if _, err := ExtractPalette(image.NewRGBA(image.Rect(0, 0, 0, 0)), 8); err == nil {
+		t.Errorf("expected error for empty image")
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	img := newTestImage(
+		color.RGBA{0x20, 0x24, 0x38, 0xff},
+		color.RGBA{0xe8, 0xe6, 0xe0, 0xff},
+		color.RGBA{0xd0, 0x40, 0x40, 0xff},
+		color.RGBA{0x50, 0xb0, 0x50, 0xff},
+		color.RGBA{0x40, 0x70, 0xe0, 0xff},
+	)
+	scheme, err := FromImage(img, ExtractOptions{Scheme: "wallpaper"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Scheme() != "wallpaper" || scheme.Variant() != VariantDark {
+		t.Errorf("unexpected metadata scheme=%s variant=%s", scheme.Scheme(), scheme.Variant())
+	}
+	for i := 1; i < 8; i++ {
+		if scheme.ColorAt(i).OKLCh().L <= scheme.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be greater than %s", ColorIndexName(i), ColorIndexName(i-1))
+		}
+	}
+	for _, i := range []int{5, 8, 9, 10, 11, 12, 13, 14, 15} {
+		if ratio := ContrastRatio(scheme.ColorAt(i), scheme.ColorAt(0)); ratio < DefaultContrast {
+			t.Errorf("%s: expected contrast >= %f, got=%f", ColorIndexName(i), DefaultContrast, ratio)
+		}
+	}
+	// accents use the image colors with the closest hue
+	for name, c := range map[string]Color{"base08": NewColor("d04040"), "base0B": NewColor("50b050"), "base0D": NewColor("4070e0")} {
+		if d := math.Abs(hueDelta(scheme.GetColor(name).OKLCh().H, c.OKLCh().H)); d > 5 {
+			t.Errorf("%s: expected hue of %s, got=%s", name, c.ToHexString(), scheme.GetColor(name).ToHexString())
+		}
+	}
+}
+
+func TestFromImageLightVariant(t *testing.T) {
+	img := newTestImage(color.RGBA{0xf0, 0xf0, 0xe8, 0xff}, color.RGBA{0x30, 0x30, 0x30, 0xff})
+	scheme, err := FromImage(img)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Variant() != VariantLight {
+		t.Errorf("expected value=%v, got=%v", VariantLight, scheme.Variant())
+	}
+	for i := 0; i < 16; i++ {
+		if scheme.ColorAt(i) == NoColor {
+			t.Errorf("expected %s to be defined", ColorIndexName(i))
+		}
+	}
+
+	scheme, err = FromImage(img, ExtractOptions{Variant: VariantDark})
+	if err != nil || scheme.Variant() != VariantDark {
+		t.Errorf("expected dark variant without error, got=%s err=%v", scheme.Variant(), err)
+	}
+	if _, err := FromImage(img, ExtractOptions{Variant: "dim"}); err == nil {
+		t.Errorf("expected error for invalid variant")
+	}
+}
@@ -0,0 +1,279 @@
+package base16
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultContrast is the default minimum WCAG contrast ratio of foreground
+	// colors (4.5 is the WCAG AA level for normal text).
+	DefaultContrast = 4.5
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// Scheme is the name of the generated scheme (defaults to "Generated").
+	Scheme string
+
+	// Author is the author of the generated scheme.
+	Author string
+
+	// Variant is either VariantDark or VariantLight. If the background seed
+	// does not match the variant, the lightness of background and foreground
+	// is inverted. Defaults to the variant of the background seed.
+	Variant string
+
+	// Contrast is the minimum WCAG contrast ratio of the default foreground
+	// (base05) and the accents (base08 - base0F) against the background
+	// (defaults to DefaultContrast).
+	Contrast float64
+}
+
+// accentHues holds the canonical OKLCh hues of the accent colors base08 -
+// base0F (red, orange, yellow, green, cyan, blue, magenta and brown).
+var accentHues = [8]float64{27, 60, 95, 140, 195, 255, 315, 50}
+
+// rampPositions holds the positions of base00 - base05 between background
+// (0) and foreground (1). base06 and base07 are placed between the foreground
+// and the extreme lightness (black or white) by rampExtension.
+var rampPositions = [6]float64{0, 0.08, 0.16, 0.4, 0.75, 1}
+var rampExtension = [2]float64{0.4, 0.8}
+
+// minRampStep is the minimum OKLCh lightness difference of neighbouring ramp
+// colors. rampMinL and rampMaxL limit the lightness of the ramp, 8-bit sRGB
+// grays darker than rampMinL are too sparse to stay distinct.
+const (
+	minRampStep = 0.02
+	rampMinL    = 0.15
+	rampMaxL    = 1
+)
+
+// Generate derives a complete base16 scheme from a background, a foreground
+// and an accent seed color. The monotone ramp base00 - base07 is interpolated
+// in OKLCh from background to foreground, the accents base08 - base0F use the
+// lightness and chroma of the accent seed with hues harmonized around the
+// accent: the seed is assigned to the accent with the closest canonical hue,
+// the hues of all other accents are rotated half way towards the seed. The
+// lightness of base05 and the accents is adjusted to reach the target
+// contrast, base05 only away from the background. Returns an error if the
+// seeds cannot form a ramp reaching the target contrast (e.g. background and
+// foreground of similar lightness).
+func Generate(background Color, foreground Color, accent Color, options ...GenerateOptions) (Scheme, error) {
+	var opts GenerateOptions
+	if len(options) == 1 {
+		opts = options[0]
+	}
+	if opts.Scheme == "" {
+		opts.Scheme = "Generated"
+	}
+	if opts.Contrast == 0 {
+		opts.Contrast = DefaultContrast
+	}
+	if opts.Contrast < 1 || opts.Contrast > 21 {
+		return nil, fmt.Errorf("contrast must be in the range [1, 21], got %g", opts.Contrast)
+	}
+	if background == NoColor || foreground == NoColor || accent == NoColor {
+		return nil, fmt.Errorf("seed colors must not be NoColor")
+	}
+
+	bg, fg := background.OKLCh(), foreground.OKLCh()
+	dark := bg.L < fg.L
+	switch opts.Variant {
+	case "":
+	case VariantDark, VariantLight:
+		if wantDark := opts.Variant == VariantDark; wantDark != (bg.L < 0.5) {
+			bg.L, fg.L = 1-bg.L, 1-fg.L
+		}
+		dark = opts.Variant == VariantDark
+	default:
+		return nil, fmt.Errorf("invalid variant %q", opts.Variant)
+	}
+	if dark != (bg.L < fg.L) || math.Abs(bg.L-fg.L) < 1e-3 {
+		return nil, fmt.Errorf("foreground and background must differ in lightness")
+	}
+
+	scheme, _ := NewScheme(opts.Scheme, opts.Author)
+	if err := setRamp(scheme, bg, fg, dark, opts.Contrast); err != nil {
+		return nil, err
+	}
+	setAccents(scheme, harmonizedAccents(accent.OKLCh()), opts.Contrast)
+	return scheme, nil
+}
+
+// setRamp sets the monotone ramp base00 - base07 of scheme and the variant
+// metadata. base00 - base05 are interpolated from bg to fg, base06 and base07
+// continue towards black (light variant) or white (dark variant). The ramp is
+// adjusted by fitRamp, returns an error if the contrast ratio of base05 cannot
+// be reached.
+func setRamp(scheme Scheme, bg OKLCh, fg OKLCh, dark bool, contrast float64) error {
+	extreme := OKLCh{L: 0, C: 0, H: fg.H}
+	scheme.SetVariant(VariantLight)
+	if dark {
+		extreme.L = 1
+		scheme.SetVariant(VariantDark)
+	}
+	var ramp [8]OKLCh
+	for i, t := range rampPositions {
+		ramp[i] = mixLCh(bg, fg, t)
+	}
+	for i, t := range rampExtension {
+		ramp[6+i] = mixLCh(fg, extreme, t)
+	}
+	if !fitRamp(&ramp, dark, contrast) {
+		return fmt.Errorf("contrast %g cannot be reached by a %s ramp on background %s", contrast, scheme.Variant(), bg.Color().ToHexString())
+	}
+	for i, lch := range ramp {
+		scheme.SetColorAt(i, lch.Color())
+	}
+	return nil
+}
+
+// fitRamp adjusts the lightness of ramp (base00 - base07) as a unit: the
+// lightness is limited to [rampMinL, rampMaxL] and strictly monotonic
+// (increasing for dark variants) with steps of at least minRampStep, base05 is
+// moved away from the background until it reaches the contrast ratio against
+// base00. Returns false if the contrast ratio cannot be reached.
+func fitRamp(ramp *[8]OKLCh, dark bool, contrast float64) bool {
+	// depth is the lightness in the direction of the ramp
+	depth := func(l float64) float64 {
+		if dark {
+			return l
+		}
+		return 1 - l
+	}
+	lo, hi := depth(rampMinL), depth(rampMaxL)
+	if !dark {
+		lo, hi = hi, lo
+	}
+	var d [8]float64
+	for i, lch := range ramp {
+		d[i] = math.Max(lo, math.Min(hi, depth(lch.L)))
+	}
+	monotonic := func() {
+		for i := 1; i < len(d); i++ {
+			d[i] = math.Max(d[i], d[i-1]+minRampStep)
+		}
+		for i := len(d) - 1; i >= 0; i-- {
+			d[i] = math.Min(d[i], hi-float64(len(d)-1-i)*minRampStep)
+		}
+	}
+	monotonic()
+
+	background := OKLCh{L: depth(d[0]), C: ramp[0].C, H: ramp[0].H}.Color()
+	fg := ramp[5]
+	for ; ; d[5] += 0.005 {
+		if d[5] > hi-2*minRampStep {
+			return false
+		}
+		fg.L = depth(d[5])
+		if ContrastRatio(fg.Color(), background) >= contrast {
+			break
+		}
+	}
+	monotonic()
+	for i := range ramp {
+		ramp[i].L = depth(d[i])
+	}
+	return true
+}
+
+// setAccents sets the accents base08 - base0F of scheme. Accents are adjusted
+// to reach the contrast ratio against base00.
+func setAccents(scheme Scheme, accents [8]OKLCh, contrast float64) {
+	background := scheme.ColorAt(0)
+	for i, lch := range accents {
+		c := lch.Color()
+		if ContrastRatio(c, background) < contrast {
+			c = fitContrast(lch, background, contrast).Color()
+		}
+		scheme.SetColorAt(8+i, c)
+	}
+}
+
+// harmonizedAccents derives the accents base08 - base0F from seed. The seed
+// is assigned to the accent with the closest canonical hue, the hues of all
+// other accents are rotated half way towards the seed.
+func harmonizedAccents(seed OKLCh) [8]OKLCh {
+	slot := 0
+	for i := 1; i < 7; i++ {
+		if math.Abs(hueDelta(seed.H, accentHues[i])) < math.Abs(hueDelta(seed.H, accentHues[slot])) {
+			slot = i
+		}
+	}
+	offset := hueDelta(accentHues[slot], seed.H)
+	chroma := math.Max(seed.C, 0.05)
+
+	var accents [8]OKLCh
+	for i, hue := range accentHues {
+		accents[i] = OKLCh{L: seed.L, C: chroma, H: math.Mod(hue+offset/2+360, 360)}
+	}
+	// brown is a darker, desaturated orange
+	accents[7].L, accents[7].C = seed.L*0.8, chroma*0.7
+	accents[slot] = seed
+	return accents
+}
+
+// mixLCh interpolates between a and b in OKLCh along the shortest hue path.
+// The hue of achromatic colors is ignored.
+func mixLCh(a OKLCh, b OKLCh, t float64) OKLCh {
+	const achromatic = 1e-4
+	switch {
+	case a.C < achromatic:
+		a.H = b.H
+	case b.C < achromatic:
+		b.H = a.H
+	}
+	return OKLCh{
+		L: a.L + (b.L-a.L)*t,
+		C: a.C + (b.C-a.C)*t,
+		H: math.Mod(a.H+hueDelta(a.H, b.H)*t+360, 360),
+	}
+}
+
+// hueDelta returns the signed shortest angle in degrees from hue a to hue b.
+func hueDelta(a float64, b float64) float64 {
+	d := math.Mod(b-a, 360)
+	if d > 180 {
+		d -= 360
+	} else if d < -180 {
+		d += 360
+	}
+	return d
+}
+
+// fitContrast returns the color with the smallest lightness change of lch
+// reaching the WCAG contrast ratio target against background. Hue and chroma
+// are preserved (as far as the sRGB gamut allows). If the target cannot be
+// reached, the color with the highest contrast (black or white) is returned.
+func fitContrast(lch OKLCh, background Color, target float64) OKLCh {
+	fitted, ok := fitLightness(lch, func(c Color) bool {
+		return ContrastRatio(c, background) >= target
+	})
+	if ok {
+		return fitted
+	}
+	if ContrastRatio(NewColor("000000"), background) > ContrastRatio(NewColor("ffffff"), background) {
+		return OKLCh{L: 0, C: 0, H: lch.H}
+	}
+	return OKLCh{L: 1, C: 0, H: lch.H}
+}
+
+// fitLightness returns the color with the smallest lightness change of lch
+// accepted by accept. Hue and chroma are preserved (as far as the sRGB gamut
+// allows). Returns false if no lightness is accepted.
+func fitLightness(lch OKLCh, accept func(c Color) bool) (OKLCh, bool) {
+	const step = 0.005
+	for d := step; d <= 1; d += step {
+		for _, l := range []float64{lch.L + d, lch.L - d} {
+			if l < 0 || l > 1 {
+				continue
+			}
+			candidate := OKLCh{L: l, C: lch.C, H: lch.H}
+			if accept(candidate.Color()) {
+				return candidate, true
+			}
+		}
+	}
+	return lch, false
+}
@@ -0,0 +1,138 @@
+//go:build !integration
+// +build !integration
+
+package base16
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	scheme, err := Generate(NewColor("1d1f21"), NewColor("c5c8c6"), NewColor("81a2be"), GenerateOptions{Scheme: "test", Author: "nobody"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Scheme() != "test" || scheme.Author() != "nobody" || scheme.Variant() != VariantDark {
+		t.Errorf("unexpected metadata scheme=%s author=%s variant=%s", scheme.Scheme(), scheme.Author(), scheme.Variant())
+	}
+	if scheme.GetColor("base00") != NewColor("1d1f21") || scheme.GetColor("base05") != NewColor("c5c8c6") {
+		t.Errorf("expected seeds to be used as base00 and base05")
+	}
+	// the accent seed is closest to blue
+	if scheme.GetColor("base0D") != NewColor("81a2be") {
+		t.Errorf("expected value=%s, got=%s", "81a2be", scheme.GetColor("base0D").ToHexString())
+	}
+
+	for i := 1; i < 8; i++ {
+		if scheme.ColorAt(i).OKLCh().L <= scheme.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be greater than %s", ColorIndexName(i), ColorIndexName(i-1))
+		}
+	}
+	for i := 8; i < 16; i++ {
+		if ratio := ContrastRatio(scheme.ColorAt(i), scheme.ColorAt(0)); ratio < DefaultContrast {
+			t.Errorf("%s: expected contrast >= %f, got=%f", ColorIndexName(i), DefaultContrast, ratio)
+		}
+		if i > 8 && scheme.ColorAt(i) == scheme.ColorAt(i-1) {
+			t.Errorf("expected accents %s and %s to differ", ColorIndexName(i-1), ColorIndexName(i))
+		}
+	}
+}
+
+func TestGenerateLightVariant(t *testing.T) {
+	scheme, err := Generate(NewColor("1d1f21"), NewColor("c5c8c6"), NewColor("cc6666"), GenerateOptions{Variant: VariantLight, Contrast: 7})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Variant() != VariantLight || DetectVariant(Clone(scheme)) != VariantLight {
+		t.Errorf("expected light variant, got=%s", scheme.Variant())
+	}
+	for i := 1; i < 8; i++ {
+		if scheme.ColorAt(i).OKLCh().L >= scheme.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be less than %s", ColorIndexName(i), ColorIndexName(i-1))
+		}
+	}
+	for _, i := range []int{5, 8, 9, 10, 11, 12, 13, 14, 15} {
+		if ratio := ContrastRatio(scheme.ColorAt(i), scheme.ColorAt(0)); ratio < 7 {
+			t.Errorf("%s: expected contrast >= %f, got=%f", ColorIndexName(i), 7.0, ratio)
+		}
+	}
+}
+
+func TestGenerateRampMonotonic(t *testing.T) {
+	testCases := map[string]struct {
+		background, foreground string
+		contrast               float64
+	}{
+		"dark":                 {"1d1f21", "c5c8c6", 0},
+		"black and white":      {"000000", "ffffff", 0},
+		"white foreground":     {"1d1f21", "ffffff", 12},
+		"white and black":      {"ffffff", "000000", 0},
+		"black foreground":     {"fdf6e3", "101010", 15},
+		"similar lightness":    {"1d1f21", "2a2c2e", 14},
+		"light similar seeds":  {"a2f158", "a4c6af", 0},
+		"light on light seeds": {"fdf6e3", "eee8d5", 7},
+	}
+	for name, tc := range testCases {
+		scheme, err := Generate(NewColor(tc.background), NewColor(tc.foreground), NewColor("81a2be"), GenerateOptions{Contrast: tc.contrast})
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+			continue
+		}
+		checkGeneratedRamp(t, name, scheme, tc.contrast)
+	}
+
+	// random seeds either fail or produce a valid ramp
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		bg := NewColorRGB(uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)))
+		fg := NewColorRGB(uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)))
+		if scheme, err := Generate(bg, fg, NewColor("81a2be")); err == nil {
+			checkGeneratedRamp(t, bg.ToHexString()+"/"+fg.ToHexString(), scheme, 0)
+		}
+	}
+}
+
+// checkGeneratedRamp checks that the ramp of scheme is strictly monotonic in
+// the direction of its variant, that the variant matches the background and
+// that base05 reaches the contrast ratio.
+func checkGeneratedRamp(t *testing.T, name string, scheme Scheme, contrast float64) {
+	t.Helper()
+	if contrast == 0 {
+		contrast = DefaultContrast
+	}
+	dark := scheme.Variant() == VariantDark
+	if variant := backgroundVariant(scheme.ColorAt(0)); variant != scheme.Variant() {
+		t.Errorf("%s: variant %s does not match background %s", name, scheme.Variant(), scheme.ColorAt(0).ToHexString())
+	}
+	for i := 1; i < 8; i++ {
+		prev, l := scheme.ColorAt(i-1).OKLCh().L, scheme.ColorAt(i).OKLCh().L
+		if dark && l <= prev || !dark && l >= prev {
+			t.Errorf("%s: expected strictly monotonic lightness, got %s=%s %s=%s", name,
+				ColorIndexName(i-1), scheme.ColorAt(i-1).ToHexString(), ColorIndexName(i), scheme.ColorAt(i).ToHexString())
+		}
+	}
+	if ratio := ContrastRatio(scheme.ColorAt(5), scheme.ColorAt(0)); ratio < contrast {
+		t.Errorf("%s: expected contrast >= %f, got=%f", name, contrast, ratio)
+	}
+}
+
+func TestGenerateErrorHandling(t *testing.T) {
+	black, white := NewColor("000000"), NewColor("ffffff")
+	testCases := map[string]struct {
+		seeds   [3]Color
+		options GenerateOptions
+	}{
+		"no color":         {seeds: [3]Color{NoColor, white, white}},
+		"invalid variant":  {seeds: [3]Color{black, white, white}, options: GenerateOptions{Variant: "dim"}},
+		"invalid contrast": {seeds: [3]Color{black, white, white}, options: GenerateOptions{Contrast: 22}},
+		"same lightness":   {seeds: [3]Color{black, black, white}},
+		"similar seeds":    {seeds: [3]Color{NewColor("a4c6af"), NewColor("a2f158"), white}},
+		"contrast":         {seeds: [3]Color{NewColor("1d1f21"), white, white}, options: GenerateOptions{Contrast: 21}},
+	}
+	for name, tc := range testCases {
+		if _, err := Generate(tc.seeds[0], tc.seeds[1], tc.seeds[2], tc.options); err == nil {
+			t.Errorf("%s: expected error not nil", name)
+		}
+	}
+}
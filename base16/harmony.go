@@ -0,0 +1,189 @@
+package base16
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Harmony is a color harmony defined by hue rotations in OKLCh.
+type Harmony int
+
+const (
+	// HarmonyComplementary is the color and its opposite hue (180°).
+	HarmonyComplementary Harmony = iota
+
+	// HarmonySplitComplementary is the color and the two hues next to its
+	// complement (150° and 210°).
+	HarmonySplitComplementary
+
+	// HarmonyAnalogous is the color and its neighbour hues (-30° and 30°).
+	HarmonyAnalogous
+
+	// HarmonyTriadic is the color and two hues evenly spaced around the hue
+	// circle (120° and 240°).
+	HarmonyTriadic
+
+	// HarmonyTetradic is the color and three hues evenly spaced around the hue
+	// circle (90°, 180° and 270°).
+	HarmonyTetradic
+)
+
+// harmonyRotations holds the hue rotations in degrees of each harmony.
+var harmonyRotations = map[Harmony][]float64{
+	HarmonyComplementary:      {0, 180},
+	HarmonySplitComplementary: {0, 150, 210},
+	HarmonyAnalogous:          {0, -30, 30},
+	HarmonyTriadic:            {0, 120, 240},
+	HarmonyTetradic:           {0, 90, 180, 270},
+}
+
+// String returns the name of the harmony.
+func (h Harmony) String() string {
+	switch h {
+	case HarmonyComplementary:
+		return "complementary"
+	case HarmonySplitComplementary:
+		return "split-complementary"
+	case HarmonyAnalogous:
+		return "analogous"
+	case HarmonyTriadic:
+		return "triadic"
+	case HarmonyTetradic:
+		return "tetradic"
+	}
+	return fmt.Sprintf("Harmony(%d)", int(h))
+}
+
+// Harmony returns the harmony set of c. The first color is c, the other
+// colors rotate the OKLCh hue of c and keep its lightness and chroma (as far
+// as the sRGB gamut allows). Returns nil for NoColor or an unknown harmony.
+func (c Color) Harmony(h Harmony) []Color {
+	rotations, ok := harmonyRotations[h]
+	if c == NoColor || !ok {
+		return nil
+	}
+	lch := c.OKLCh()
+	colors := make([]Color, len(rotations))
+	colors[0] = c
+	for i, rotation := range rotations[1:] {
+		colors[i+1] = OKLCh{L: lch.L, C: lch.C, H: math.Mod(lch.H+rotation+360, 360)}.Color()
+	}
+	return colors
+}
+
+// hueClusterDistance is the maximum hue distance in degrees of neighbouring
+// accents in a cluster, hueGapSize the minimum size in degrees of a gap.
+const (
+	hueClusterDistance = 20
+	hueGapSize         = 60
+)
+
+// AccentHue is the hue of an accent.
+type AccentHue struct {
+	Name   string
+	Hue    float64
+	Chroma float64
+}
+
+// HueGap is an empty range of the hue circle between two neighbouring accents.
+type HueGap struct {
+	// From and To are the accents before and after the gap in hue order.
+	From string
+	To   string
+
+	// Start is the hue of From, Size the size of the gap in degrees.
+	Start float64
+	Size  float64
+}
+
+// HueAnalysis describes how the accents of a scheme relate by hue.
+type HueAnalysis struct {
+	// Hues contains the chromatic accents ordered by hue.
+	Hues []AccentHue
+
+	// Achromatic contains the names of the accents which are too gray to have
+	// a meaningful hue.
+	Achromatic []string
+
+	// Spread is the size in degrees of the smallest hue range containing all
+	// chromatic accents (0 for a single accent, up to 360).
+	Spread float64
+
+	// Clusters contains groups of at least two accents with neighbouring hues
+	// closer than 20°, in hue order.
+	Clusters [][]string
+
+	// Gaps contains the hue ranges of at least 60° without an accent, ordered
+	// by descending size.
+	Gaps []HueGap
+}
+
+// AnalyzeHues analyzes the hues of the accents base08 - base0F of scheme.
+// Undefined accents are skipped.
+func AnalyzeHues(scheme Scheme) *HueAnalysis {
+	analysis := &HueAnalysis{}
+	for i := 8; i < Base16DefaultColors; i++ {
+		c := scheme.ColorAt(i)
+		if c == NoColor {
+			continue
+		}
+		lch := c.OKLCh()
+		if lch.C < minAccentChroma {
+			analysis.Achromatic = append(analysis.Achromatic, ColorIndexName(i))
+			continue
+		}
+		analysis.Hues = append(analysis.Hues, AccentHue{Name: ColorIndexName(i), Hue: lch.H, Chroma: lch.C})
+	}
+	hues := analysis.Hues
+	sort.SliceStable(hues, func(i, j int) bool {
+		return hues[i].Hue < hues[j].Hue
+	})
+	if len(hues) < 2 {
+		return analysis
+	}
+
+	// gaps between neighbouring hues, the last gap wraps around and spans the
+	// full circle if all hues are equal
+	gaps := make([]HueGap, len(hues))
+	largest := 0.0
+	for i, from := range hues {
+		to := hues[(i+1)%len(hues)]
+		size := math.Mod(to.Hue-from.Hue+360, 360)
+		if i == len(hues)-1 && hues[0].Hue == from.Hue {
+			size = 360
+		}
+		gaps[i] = HueGap{From: from.Name, To: to.Name, Start: from.Hue, Size: size}
+		largest = math.Max(largest, size)
+		if size >= hueGapSize {
+			analysis.Gaps = append(analysis.Gaps, gaps[i])
+		}
+	}
+	analysis.Spread = 360 - largest
+	sort.SliceStable(analysis.Gaps, func(i, j int) bool {
+		return analysis.Gaps[i].Size > analysis.Gaps[j].Size
+	})
+
+	// clusters are runs of small gaps, start after a large gap so clusters
+	// crossing 0° are not split
+	start := 0
+	for i, gap := range gaps {
+		if gap.Size >= hueClusterDistance {
+			start = (i + 1) % len(hues)
+			break
+		}
+	}
+	cluster := []string{hues[start].Name}
+	for k := 0; k < len(hues); k++ {
+		i := (start + k) % len(hues)
+		if gaps[i].Size < hueClusterDistance && k < len(hues)-1 {
+			cluster = append(cluster, hues[(i+1)%len(hues)].Name)
+			continue
+		}
+		if len(cluster) > 1 {
+			analysis.Clusters = append(analysis.Clusters, cluster)
+		}
+		cluster = []string{hues[(i+1)%len(hues)].Name}
+	}
+	return analysis
+}
@@ -0,0 +1,107 @@
+// +build !integration
+
+package base16
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestColorHarmony(t *testing.T) {
+	c := NewColor("cc6666")
+	lch := c.OKLCh()
+	testCases := []struct {
+		harmony   Harmony
+		rotations []float64
+	}{
+		{HarmonyComplementary, []float64{0, 180}},
+		{HarmonySplitComplementary, []float64{0, 150, 210}},
+		{HarmonyAnalogous, []float64{0, -30, 30}},
+		{HarmonyTriadic, []float64{0, 120, 240}},
+		{HarmonyTetradic, []float64{0, 90, 180, 270}},
+	}
+	for _, tc := range testCases {
+		colors := c.Harmony(tc.harmony)
+		if len(colors) != len(tc.rotations) || colors[0] != c {
+			t.Fatalf("%s: unexpected colors %v", tc.harmony, colors)
+		}
+		for i, rotation := range tc.rotations {
+			h := colors[i].OKLCh()
+			if d := math.Abs(hueDelta(lch.H+rotation, h.H)); d > 2 {
+				t.Errorf("%s: expected hue=%f, got=%f", tc.harmony, lch.H+rotation, h.H)
+			}
+			if math.Abs(h.L-lch.L) > 0.01 {
+				t.Errorf("%s: expected lightness=%f, got=%f", tc.harmony, lch.L, h.L)
+			}
+		}
+	}
+	if colors := NoColor.Harmony(HarmonyTriadic); colors != nil {
+		t.Errorf("expected nil, got=%v", colors)
+	}
+	if colors := c.Harmony(Harmony(42)); colors != nil || Harmony(42).String() != "Harmony(42)" {
+		t.Errorf("expected nil for unknown harmony, got=%v", colors)
+	}
+}
+
+func TestAnalyzeHues(t *testing.T) {
+	scheme := newTestScheme("hues", "", "1d1f21")
+	accents := map[string]OKLCh{
+		"base08": {L: 0.65, C: 0.15, H: 5},
+		"base09": {L: 0.65, C: 0.15, H: 350},
+		"base0A": {L: 0.65, C: 0.15, H: 100},
+		"base0B": {L: 0.65, C: 0.15, H: 112},
+		"base0C": {L: 0.65, C: 0.15, H: 200},
+		"base0D": {L: 0.65, C: 0.15, H: 270},
+	}
+	for name, lch := range accents {
+		scheme.SetColor(name, lch.Color())
+	}
+	scheme.SetColor("base0E", NewColor("808080"))
+	scheme.SetColor("base0F", NoColor)
+
+	analysis := AnalyzeHues(scheme)
+	names := []string{}
+	for _, hue := range analysis.Hues {
+		names = append(names, hue.Name)
+	}
+	if expected := []string{"base08", "base0A", "base0B", "base0C", "base0D", "base09"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected value=%v, got=%v", expected, names)
+	}
+	if !reflect.DeepEqual(analysis.Achromatic, []string{"base0E"}) {
+		t.Errorf("expected value=%v, got=%v", []string{"base0E"}, analysis.Achromatic)
+	}
+	// the largest gap is between red (5°) and yellow (100°)
+	if math.Abs(analysis.Spread-(360-95)) > 2 {
+		t.Errorf("expected spread=%f, got=%f", 360-95.0, analysis.Spread)
+	}
+	expectedClusters := [][]string{{"base0A", "base0B"}, {"base09", "base08"}}
+	if !reflect.DeepEqual(analysis.Clusters, expectedClusters) {
+		t.Errorf("expected value=%v, got=%v", expectedClusters, analysis.Clusters)
+	}
+	if len(analysis.Gaps) != 4 || analysis.Gaps[0].From != "base08" || analysis.Gaps[0].To != "base0A" {
+		t.Errorf("unexpected gaps %+v", analysis.Gaps)
+	}
+	for _, gap := range analysis.Gaps {
+		if gap.Size < 60 {
+			t.Errorf("unexpected gap %+v", gap)
+		}
+	}
+
+	// accents with equal hues have no spread
+	same := newTestScheme("same", "", "1d1f21")
+	same.SetColor("base08", NewColor("ff0000"))
+	same.SetColor("base09", NewColor("ff0000"))
+	analysis = AnalyzeHues(same)
+	if analysis.Spread != 0 || len(analysis.Gaps) != 1 || analysis.Gaps[0].Size != 360 {
+		t.Errorf("unexpected analysis %+v", analysis)
+	}
+	if expected := [][]string{{"base08", "base09"}}; !reflect.DeepEqual(analysis.Clusters, expected) {
+		t.Errorf("expected value=%v, got=%v", expected, analysis.Clusters)
+	}
+
+	empty, _ := NewScheme("empty", "")
+	if analysis := AnalyzeHues(empty); len(analysis.Hues) != 0 || analysis.Spread != 0 {
+		t.Errorf("unexpected analysis %+v", analysis)
+	}
+}
@@ -0,0 +1,27 @@
+// +build !integration
+
+package base16
+
+// newTestScheme returns a scheme with all colors set to gray except the
+// background.
+func newTestScheme(name string, author string, background string) Scheme {
+	scheme, _ := NewScheme(name, author)
+	for _, colorname := range scheme.GetColorNames() {
+		scheme.SetColor(colorname, NewColor("808080"))
+	}
+	scheme.SetColor("base00", NewColor(background))
+	return scheme
+}
+
+// newTomorrowNight returns the Tomorrow Night scheme.
+func newTomorrowNight() Scheme {
+	scheme, _ := NewScheme("Tomorrow Night", "Chris Kempson")
+	colors := []string{
+		"1d1f21", "282a2e", "373b41", "969896", "b4b7b4", "c5c8c6", "e0e0e0", "ffffff",
+		"cc6666", "de935f", "f0c674", "b5bd68", "8abeb7", "81a2be", "b294bb", "a3685a",
+	}
+	for i, c := range colors {
+		scheme.SetColorAt(i, NewColor(c))
+	}
+	return scheme
+}
@@ -0,0 +1,234 @@
+package base16
+
+import (
+	"fmt"
+)
+
+const (
+	// DefaultHistoryLimit specifies the default number of undo steps of a
+	// HistoryScheme.
+	DefaultHistoryLimit = 100
+)
+
+// HistoryScheme implements the Scheme interface by wrapping another Scheme and
+// recording all changes for undo and redo. Changes can be grouped into
+// transactions which are undone and redone as a single step. Setting a value
+// equal to the current value is not recorded. HistoryScheme is not safe for
+// concurrent use.
+type HistoryScheme struct {
+	scheme Scheme
+	limit  int
+	undo   [][]SchemeChange
+	redo   [][]SchemeChange
+
+	// group collects the changes of the open transaction, depth counts nested
+	// Begin calls.
+	group []SchemeChange
+	depth int
+}
+
+// NewHistoryScheme returns a new history tracking scheme wrapping scheme. The
+// optional argument limits the number of undo steps (defaults to
+// DefaultHistoryLimit), the oldest steps are discarded first.
+func NewHistoryScheme(scheme Scheme, limit ...int) *HistoryScheme {
+	h := &HistoryScheme{scheme: scheme, limit: DefaultHistoryLimit}
+	if len(limit) == 1 && limit[0] > 0 {
+		h.limit = limit[0]
+	}
+	return h
+}
+
+// Author returns the author of the scheme
+func (h *HistoryScheme) Author() string {
+	return h.scheme.Author()
+}
+
+// SetAuthor sets the author name of the scheme
+func (h *HistoryScheme) SetAuthor(name string) {
+	h.record(SchemeChange{Name: FieldAuthor, OldColor: NoColor, NewColor: NoColor, OldValue: h.scheme.Author(), NewValue: name})
+}
+
+// Scheme returns the scheme identifier (name)
+func (h *HistoryScheme) Scheme() string {
+	return h.scheme.Scheme()
+}
+
+// SetScheme sets the scheme identifier of the scheme
+func (h *HistoryScheme) SetScheme(name string) {
+	h.record(SchemeChange{Name: FieldScheme, OldColor: NoColor, NewColor: NoColor, OldValue: h.scheme.Scheme(), NewValue: name})
+}
+
+// Variant returns the scheme variant (e.g. "dark" or "light")
+func (h *HistoryScheme) Variant() string {
+	return h.scheme.Variant()
+}
+
+// SetVariant sets the variant of the scheme
+func (h *HistoryScheme) SetVariant(variant string) {
+	h.record(SchemeChange{Name: FieldVariant, OldColor: NoColor, NewColor: NoColor, OldValue: h.scheme.Variant(), NewValue: variant})
+}
+
+// CountColors returns the number of colors
+func (h *HistoryScheme) CountColors() int {
+	return h.scheme.CountColors()
+}
+
+// GetColor returns the color specified by colorname
+func (h *HistoryScheme) GetColor(colorname string) Color {
+	return h.scheme.GetColor(colorname)
+}
+
+// GetColorNames returns a sorted string slice of all color names
+func (h *HistoryScheme) GetColorNames() []string {
+	return h.scheme.GetColorNames()
+}
+
+// SetColor sets the color c for color name. Returns an error if colorname is
+// not a valid color name (see ColorNameIndex) or exceeds the number of colors
+// of the scheme, invalid color names are not recorded.
+func (h *HistoryScheme) SetColor(colorname string, c Color) error {
+	if ColorNameIndex(colorname) < 0 {
+		return fmt.Errorf("invalid color name %q", colorname)
+	}
+	return h.record(SchemeChange{Name: canonicalColorName(colorname), OldColor: h.scheme.GetColor(colorname), NewColor: c})
+}
+
+// ColorAt returns the color at index (zero based)
+func (h *HistoryScheme) ColorAt(index int) Color {
+	return h.scheme.ColorAt(index)
+}
+
+// SetColorAt sets the color c at index (zero based)
+func (h *HistoryScheme) SetColorAt(index int, c Color) error {
+	if index < 0 || index >= h.scheme.CountColors() {
+		return h.scheme.SetColorAt(index, c)
+	}
+	return h.SetColor(ColorIndexName(index), c)
+}
+
+// Colors returns all colors with their names ordered by index
+func (h *HistoryScheme) Colors() []NamedColor {
+	return h.scheme.Colors()
+}
+
+// ExtendedModeOn returns the extended mode flag
+func (h *HistoryScheme) ExtendedModeOn() bool {
+	return h.scheme.ExtendedModeOn()
+}
+
+// Begin starts a transaction. All changes until the matching Commit are
+// undone and redone as a single step. Transactions can be nested, only the
+// outermost transaction creates an undo step.
+func (h *HistoryScheme) Begin() {
+	h.depth++
+}
+
+// Commit ends the transaction started by Begin.
+func (h *HistoryScheme) Commit() {
+	if h.depth == 0 {
+		return
+	}
+	h.depth--
+	if h.depth == 0 && len(h.group) > 0 {
+		h.push(h.group)
+		h.group = nil
+	}
+}
+
+// Transaction calls f within a transaction (see Begin). f should apply its
+// changes to the passed scheme.
+func (h *HistoryScheme) Transaction(f func(scheme Scheme)) {
+	h.Begin()
+	defer h.Commit()
+	f(h)
+}
+
+// CanUndo returns true if there is a step to undo.
+func (h *HistoryScheme) CanUndo() bool {
+	return h.depth == 0 && len(h.undo) > 0
+}
+
+// CanRedo returns true if there is a step to redo.
+func (h *HistoryScheme) CanRedo() bool {
+	return h.depth == 0 && len(h.redo) > 0
+}
+
+// Undo reverts the last step. Returns false if there is nothing to undo or a
+// transaction is open.
+func (h *HistoryScheme) Undo() bool {
+	if !h.CanUndo() {
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	for i := len(step) - 1; i >= 0; i-- {
+		applyChange(h.scheme, step[i], true)
+	}
+	h.redo = append(h.redo, step)
+	return true
+}
+
+// Redo applies the last undone step again. Returns false if there is nothing
+// to redo or a transaction is open.
+func (h *HistoryScheme) Redo() bool {
+	if !h.CanRedo() {
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	for _, change := range step {
+		applyChange(h.scheme, change, false)
+	}
+	h.undo = append(h.undo, step)
+	return true
+}
+
+// ClearHistory discards all undo and redo steps.
+func (h *HistoryScheme) ClearHistory() {
+	h.undo = nil
+	h.redo = nil
+}
+
+// record applies change and adds it to the history.
+func (h *HistoryScheme) record(change SchemeChange) error {
+	if change.OldColor == change.NewColor && change.OldValue == change.NewValue {
+		return nil
+	}
+	if err := applyChange(h.scheme, change, false); err != nil {
+		return err
+	}
+	if h.depth > 0 {
+		h.group = append(h.group, change)
+		return nil
+	}
+	h.push([]SchemeChange{change})
+	return nil
+}
+
+func (h *HistoryScheme) push(step []SchemeChange) {
+	h.redo = nil
+	h.undo = append(h.undo, step)
+	if len(h.undo) > h.limit {
+		h.undo = append([][]SchemeChange(nil), h.undo[len(h.undo)-h.limit:]...)
+	}
+}
+
+// applyChange applies the new value of change to scheme, or the old value if
+// reverse is true.
+func applyChange(scheme Scheme, change SchemeChange, reverse bool) error {
+	color, value := change.NewColor, change.NewValue
+	if reverse {
+		color, value = change.OldColor, change.OldValue
+	}
+	switch change.Name {
+	case FieldScheme:
+		scheme.SetScheme(value)
+	case FieldAuthor:
+		scheme.SetAuthor(value)
+	case FieldVariant:
+		scheme.SetVariant(value)
+	default:
+		return scheme.SetColor(change.Name, color)
+	}
+	return nil
+}
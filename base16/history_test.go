@@ -0,0 +1,146 @@
+// +build !integration
+
+package base16
+
+import (
+	"testing"
+)
+
+func TestHistorySchemeUndoRedo(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewHistoryScheme(data)
+
+	if scheme.Undo() || scheme.Redo() {
+		t.Fatalf("expected empty history")
+	}
+
+	scheme.SetColor("base00", NewColor("181818"))
+	scheme.SetColor("base00", NewColor("282828"))
+	scheme.SetColor("base00", NewColor("282828"))
+	scheme.SetAuthor("fred")
+
+	if !scheme.Undo() || scheme.Author() != "nobody" {
+		t.Errorf("expected author to be reverted, got=%s", scheme.Author())
+	}
+	if !scheme.Undo() || scheme.GetColor("base00") != NewColor("181818") {
+		t.Errorf("expected base00 to be reverted, got=%s", scheme.GetColor("base00").ToHexString())
+	}
+	if !scheme.Undo() || scheme.GetColor("base00") != NoColor {
+		t.Errorf("expected base00 to be NoColor, got=%d", scheme.GetColor("base00"))
+	}
+	if scheme.CanUndo() || scheme.Undo() {
+		t.Errorf("expected nothing to undo")
+	}
+
+	if !scheme.Redo() || scheme.GetColor("base00") != NewColor("181818") {
+		t.Errorf("expected base00 to be redone, got=%s", scheme.GetColor("base00").ToHexString())
+	}
+
+	// a new change discards the redo steps
+	scheme.SetScheme("changed")
+	if scheme.CanRedo() {
+		t.Errorf("expected nothing to redo")
+	}
+	if !scheme.Undo() || scheme.Scheme() != "test" {
+		t.Errorf("expected scheme name to be reverted, got=%s", scheme.Scheme())
+	}
+}
+
+func TestHistorySchemeTransaction(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewHistoryScheme(data)
+
+	scheme.Transaction(func(s Scheme) {
+		s.SetColor("base00", NewColor("181818"))
+		s.SetColor("base01", NewColor("282828"))
+		s.SetVariant(VariantDark)
+		if scheme.Undo() {
+			t.Errorf("expected Undo to fail within a transaction")
+		}
+	})
+
+	scheme.Begin()
+	scheme.Begin()
+	scheme.SetColor("base02", NewColor("383838"))
+	scheme.Commit()
+	scheme.SetColor("base03", NewColor("585858"))
+	scheme.Commit()
+	scheme.Commit()
+
+	if !scheme.Undo() || scheme.GetColor("base02") != NoColor || scheme.GetColor("base03") != NoColor {
+		t.Errorf("expected nested transaction to be undone as a single step")
+	}
+	if !scheme.Undo() {
+		t.Fatalf("expected transaction to be undone")
+	}
+	for _, colorname := range []string{"base00", "base01"} {
+		if scheme.GetColor(colorname) != NoColor {
+			t.Errorf("expected %s to be NoColor, got=%s", colorname, scheme.GetColor(colorname).ToHexString())
+		}
+	}
+	if scheme.Variant() != "" || scheme.CanUndo() {
+		t.Errorf("expected transaction to be undone as a single step")
+	}
+
+	if !scheme.Redo() || scheme.GetColor("base01") != NewColor("282828") || scheme.Variant() != "dark" {
+		t.Errorf("expected transaction to be redone")
+	}
+}
+
+func TestHistorySchemeLimit(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewHistoryScheme(data, 3)
+
+	for i := 0; i < 5; i++ {
+		scheme.SetColor("base00", NewColorRGB(uint8(i), 0, 0))
+	}
+	steps := 0
+	for scheme.Undo() {
+		steps++
+	}
+	if steps != 3 {
+		t.Errorf("expected value=%d, got=%d", 3, steps)
+	}
+	if scheme.GetColor("base00") != NewColorRGB(1, 0, 0) {
+		t.Errorf("expected value=%s, got=%s", "010000", scheme.GetColor("base00").ToHexString())
+	}
+
+	scheme.ClearHistory()
+	if scheme.CanUndo() || scheme.CanRedo() {
+		t.Errorf("expected history to be empty")
+	}
+}
+
+func TestHistorySchemeInvalidColor(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewHistoryScheme(data)
+
+	if err := scheme.SetColor("base99", NewColor("ff0000")); err == nil {
+		t.Errorf("expected error not nil")
+	}
+	// metadata field names are not color names
+	for _, colorname := range []string{FieldScheme, FieldAuthor, FieldVariant} {
+		if err := scheme.SetColor(colorname, NewColor("ff0000")); err == nil {
+			t.Errorf("%s: expected error not nil", colorname)
+		}
+	}
+	if scheme.CanUndo() {
+		t.Errorf("expected invalid change not to be recorded")
+	}
+	if scheme.Scheme() != "test" || scheme.Author() != "nobody" {
+		t.Errorf("expected metadata to be unchanged, got scheme=%s author=%s", scheme.Scheme(), scheme.Author())
+	}
+}
+
+func TestHistorySchemeSetColorAt(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewHistoryScheme(data)
+
+	scheme.SetColorAt(10, NewColor("f7ca88"))
+	if err := scheme.SetColorAt(16, NewColor("f7ca88")); err == nil {
+		t.Errorf("expected error not nil")
+	}
+	if !scheme.Undo() || scheme.ColorAt(10) != NoColor {
+		t.Errorf("expected SetColorAt to be undone")
+	}
+}
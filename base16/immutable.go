@@ -0,0 +1,210 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImmutableScheme is a read-only base16 scheme. It can be copied and shared
+// freely, e.g. across API or goroutine boundaries. The only way to derive a
+// changed scheme is a Builder (see ImmutableScheme.Builder). The zero value is
+// an empty scheme without colors.
+type ImmutableScheme struct {
+	scheme  string
+	author  string
+	variant string
+	colors  []Color
+}
+
+// Freeze returns an immutable copy of scheme. Returns an error if scheme does
+// not pass the validation of Builder.Build.
+func Freeze(scheme Scheme) (ImmutableScheme, error) {
+	return NewBuilderFrom(scheme).Build()
+}
+
+// Author returns the author of the scheme
+func (s ImmutableScheme) Author() string {
+	return s.author
+}
+
+// Scheme returns the scheme identifier (name)
+func (s ImmutableScheme) Scheme() string {
+	return s.scheme
+}
+
+// Variant returns the scheme variant (e.g. "dark" or "light")
+func (s ImmutableScheme) Variant() string {
+	return s.variant
+}
+
+// CountColors returns the number of colors
+func (s ImmutableScheme) CountColors() int {
+	return len(s.colors)
+}
+
+// GetColor returns the color specified by colorname. Returns NoColor if the
+// scheme does not define colorname.
+func (s ImmutableScheme) GetColor(colorname string) Color {
+	return s.ColorAt(ColorNameIndex(colorname))
+}
+
+// ColorAt returns the color at index (zero based). Returns NoColor if the index
+// is out of range.
+func (s ImmutableScheme) ColorAt(index int) Color {
+	if index < 0 || index >= len(s.colors) {
+		return NoColor
+	}
+	return s.colors[index]
+}
+
+// Colors returns all colors with their names ordered by index
+func (s ImmutableScheme) Colors() []NamedColor {
+	colors := make([]NamedColor, len(s.colors))
+	for i, c := range s.colors {
+		colors[i] = NamedColor{Name: ColorIndexName(i), Color: c}
+	}
+	return colors
+}
+
+// GetColorNames returns a sorted string slice of all color names
+func (s ImmutableScheme) GetColorNames() []string {
+	return ColorNames(len(s.colors))
+}
+
+// ExtendedModeOn returns the extended mode flag
+func (s ImmutableScheme) ExtendedModeOn() bool {
+	return len(s.colors) > Base16DefaultColors
+}
+
+// ToScheme returns a mutable copy of the scheme.
+func (s ImmutableScheme) ToScheme() Scheme {
+	count := len(s.colors)
+	if count < Base16DefaultColors {
+		count = Base16DefaultColors
+	}
+	scheme, _ := NewScheme(s.scheme, s.author, count)
+	scheme.SetVariant(s.variant)
+	for i, c := range s.colors {
+		scheme.SetColorAt(i, c)
+	}
+	return scheme
+}
+
+// Builder returns a new Builder initialized with the data of the scheme.
+func (s ImmutableScheme) Builder() *Builder {
+	return &Builder{
+		scheme:  s.scheme,
+		author:  s.author,
+		variant: s.variant,
+		colors:  append([]Color(nil), s.colors...),
+	}
+}
+
+// Builder creates ImmutableScheme values. The With methods can be chained,
+// errors are collected and returned by Build.
+type Builder struct {
+	scheme  string
+	author  string
+	variant string
+	colors  []Color
+	errs    []string
+}
+
+// NewBuilder returns a new Builder for a scheme with all colors set to
+// NoColor. The optional argument sets the number of colors (see NewScheme).
+func NewBuilder(countColorsOverride ...int) *Builder {
+	b := &Builder{}
+	countColors := Base16DefaultColors
+	if len(countColorsOverride) == 1 {
+		countColors = countColorsOverride[0]
+		if countColors > ExtendedModeMaxColors || countColors < Base16DefaultColors {
+			b.errorf(
+				"scheme must have at least %d colors and at most %d colors",
+				Base16DefaultColors,
+				ExtendedModeMaxColors,
+			)
+			countColors = Base16DefaultColors
+		}
+	}
+	b.colors = make([]Color, countColors)
+	for i := range b.colors {
+		b.colors[i] = NoColor
+	}
+	return b
+}
+
+// NewBuilderFrom returns a new Builder initialized with the data of scheme.
+func NewBuilderFrom(scheme Scheme) *Builder {
+	b := NewBuilder(scheme.CountColors())
+	b.scheme = scheme.Scheme()
+	b.author = scheme.Author()
+	b.variant = scheme.Variant()
+	for i := range b.colors {
+		b.colors[i] = scheme.ColorAt(i)
+	}
+	return b
+}
+
+// WithScheme sets the scheme identifier (name).
+func (b *Builder) WithScheme(name string) *Builder {
+	b.scheme = name
+	return b
+}
+
+// WithAuthor sets the author of the scheme.
+func (b *Builder) WithAuthor(name string) *Builder {
+	b.author = name
+	return b
+}
+
+// WithVariant sets the variant of the scheme. The variant is case insensitive,
+// Build converts it to lower case (e.g. "Dark" becomes VariantDark).
+func (b *Builder) WithVariant(variant string) *Builder {
+	b.variant = variant
+	return b
+}
+
+// WithColor sets the color c for colorname.
+func (b *Builder) WithColor(colorname string, c Color) *Builder {
+	index := ColorNameIndex(colorname)
+	if index < 0 || index >= len(b.colors) {
+		b.errorf("invalid color name %q", colorname)
+		return b
+	}
+	b.colors[index] = c
+	return b
+}
+
+// Build validates the data of the builder and returns a new ImmutableScheme.
+// Build fails if the scheme name is empty, a color is not defined, the variant
+// is neither empty, VariantDark nor VariantLight (case insensitive) or if any
+// With method failed.
+// The builder can be reused after Build.
+func (b *Builder) Build() (ImmutableScheme, error) {
+	errs := append([]string(nil), b.errs...)
+	if b.scheme == "" {
+		errs = append(errs, "scheme name must not be empty")
+	}
+	variant := strings.ToLower(b.variant)
+	if variant != "" && variant != VariantDark && variant != VariantLight {
+		errs = append(errs, fmt.Sprintf("invalid variant %q", b.variant))
+	}
+	for i, c := range b.colors {
+		if c == NoColor {
+			errs = append(errs, fmt.Sprintf("color %s is not defined", ColorIndexName(i)))
+		}
+	}
+	if len(errs) > 0 {
+		return ImmutableScheme{}, fmt.Errorf("invalid scheme: %s", strings.Join(errs, "; "))
+	}
+	return ImmutableScheme{
+		scheme:  b.scheme,
+		author:  b.author,
+		variant: variant,
+		colors:  append([]Color(nil), b.colors...),
+	}, nil
+}
+
+func (b *Builder) errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,101 @@
+// +build !integration
+
+package base16
+
+import (
+	"testing"
+)
+
+func newTestBuilder() *Builder {
+	b := NewBuilder().WithScheme("test").WithAuthor("nobody")
+	for i := 0; i < Base16DefaultColors; i++ {
+		b.WithColor(ColorIndexName(i), NewColorRGB(uint8(i), 0, 0))
+	}
+	return b
+}
+
+func TestBuilder(t *testing.T) {
+	scheme, err := newTestBuilder().WithVariant(VariantDark).WithColor("base0A", NewColor("f7ca88")).Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Scheme() != "test" || scheme.Author() != "nobody" || scheme.Variant() != "dark" {
+		t.Errorf("unexpected metadata scheme=%s author=%s variant=%s", scheme.Scheme(), scheme.Author(), scheme.Variant())
+	}
+	if scheme.CountColors() != 16 || scheme.ExtendedModeOn() {
+		t.Errorf("expected 16 colors in standard mode, got=%d", scheme.CountColors())
+	}
+	if scheme.GetColor("base0A") != NewColor("f7ca88") {
+		t.Errorf("expected value=%s, got=%s", "f7ca88", scheme.GetColor("base0A").ToHexString())
+	}
+	if scheme.GetColor("base10") != NoColor {
+		t.Errorf("expected undefined color to be NoColor")
+	}
+
+	// the variant is case insensitive
+	scheme, err = newTestBuilder().WithVariant("Light").Build()
+	if err != nil || scheme.Variant() != VariantLight {
+		t.Errorf("expected variant=%s, got=%s (%v)", VariantLight, scheme.Variant(), err)
+	}
+}
+
+func TestBuilderValidation(t *testing.T) {
+	testCases := map[string]*Builder{
+		"missing color":   NewBuilder().WithScheme("test"),
+		"missing name":    newTestBuilder().WithScheme(""),
+		"invalid color":   newTestBuilder().WithColor("base10", NewColor("000000")),
+		"invalid variant": newTestBuilder().WithVariant("dim"),
+		"invalid count":   NewBuilder(33),
+	}
+	for name, b := range testCases {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: expected error not nil", name)
+		}
+	}
+}
+
+func TestImmutableSchemeBuilder(t *testing.T) {
+	scheme, _ := newTestBuilder().Build()
+
+	changed, err := scheme.Builder().WithAuthor("fred").WithColor("base00", NewColor("ffffff")).Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if changed.Author() != "fred" || changed.GetColor("base00") != NewColor("ffffff") {
+		t.Errorf("expected builder changes to be applied")
+	}
+	if scheme.Author() != "nobody" || scheme.GetColor("base00") != NewColorRGB(0, 0, 0) {
+		t.Errorf("expected original scheme to be unchanged")
+	}
+}
+
+func TestImmutableSchemeConversion(t *testing.T) {
+	mutable, _ := NewScheme("test", "nobody", 18)
+	for _, colorname := range mutable.GetColorNames() {
+		mutable.SetColor(colorname, NewColor("181818"))
+	}
+	mutable.SetVariant(VariantDark)
+
+	scheme, err := Freeze(mutable)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	mutable.SetColor("base00", NewColor("ffffff"))
+	if scheme.GetColor("base00") != NewColor("181818") {
+		t.Errorf("expected frozen scheme to be unchanged")
+	}
+	if !scheme.ExtendedModeOn() || scheme.CountColors() != 18 {
+		t.Errorf("expected 18 colors in extended mode, got=%d", scheme.CountColors())
+	}
+
+	back := scheme.ToScheme()
+	mutable.SetColor("base00", NewColor("181818"))
+	if !Equal(mutable, back) {
+		t.Errorf("expected converted scheme to be equal, diff=%v", Diff(mutable, back))
+	}
+
+	empty, _ := NewScheme("test", "nobody")
+	if _, err := Freeze(empty); err == nil {
+		t.Errorf("expected error for scheme without colors")
+	}
+}
@@ -0,0 +1,75 @@
+package base16
+
+import (
+	"math"
+)
+
+// ColorSpace defines the color space used to mix colors.
+type ColorSpace int
+
+const (
+	// ColorSpaceOKLab mixes colors along a straight line in OKLab.
+	ColorSpaceOKLab ColorSpace = iota
+
+	// ColorSpaceOKLCh mixes lightness, chroma and hue separately in OKLCh. The
+	// hue follows the shortest path around the hue circle.
+	ColorSpaceOKLCh
+)
+
+// Mix returns the color between c (t = 0) and other (t = 1) in the given color
+// space. t is clamped to [0, 1]. If one of the colors is NoColor, the other
+// color is returned.
+func (c Color) Mix(other Color, t float64, space ColorSpace) Color {
+	switch {
+	case c == NoColor:
+		return other
+	case other == NoColor:
+		return c
+	}
+	t = math.Max(0, math.Min(1, t))
+	if space == ColorSpaceOKLCh {
+		return mixLCh(c.OKLCh(), other.OKLCh(), t).Color()
+	}
+	a, b := c.OKLab(), other.OKLab()
+	return OKLab{
+		L: a.L + (b.L-a.L)*t,
+		A: a.A + (b.A-a.A)*t,
+		B: a.B + (b.B-a.B)*t,
+	}.Color()
+}
+
+// Interpolate returns a new scheme between a (t = 0) and b (t = 1). Each color
+// is mixed in the given color space (see Color.Mix), t is clamped to [0, 1].
+// The result has the colors of both schemes, colors defined by only one
+// scheme are copied. Scheme name and author are taken from the closer scheme,
+// the variant is detected from the interpolated background (see
+// DetectVariant).
+func Interpolate(a Scheme, b Scheme, t float64, space ColorSpace) Scheme {
+	t = math.Max(0, math.Min(1, t))
+	names := unionColorNames(a, b)
+	closer := a
+	if t >= 0.5 {
+		closer = b
+	}
+
+	scheme, _ := NewScheme(closer.Scheme(), closer.Author(), len(names))
+	for i := range names {
+		scheme.SetColorAt(i, a.ColorAt(i).Mix(b.ColorAt(i), t, space))
+	}
+	scheme.SetVariant(DetectVariant(scheme))
+	return scheme
+}
+
+// InterpolateSteps returns n schemes evenly spaced between a and b, e.g. to
+// fade from a light to a dark scheme. The schemes a and b are not included,
+// step i (zero based) is Interpolate(a, b, (i+1)/(n+1), space).
+func InterpolateSteps(a Scheme, b Scheme, n int, space ColorSpace) []Scheme {
+	if n <= 0 {
+		return nil
+	}
+	steps := make([]Scheme, n)
+	for i := range steps {
+		steps[i] = Interpolate(a, b, float64(i+1)/float64(n+1), space)
+	}
+	return steps
+}
@@ -0,0 +1,96 @@
+// +build !integration
+
+package base16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestColorMix(t *testing.T) {
+	black, white := NewColor("000000"), NewColor("ffffff")
+	for _, space := range []ColorSpace{ColorSpaceOKLab, ColorSpaceOKLCh} {
+		if c := black.Mix(white, 0, space); c != black {
+			t.Errorf("expected value=%s, got=%s", black.ToHexString(), c.ToHexString())
+		}
+		if c := black.Mix(white, 1.5, space); c != white {
+			t.Errorf("expected value=%s, got=%s", white.ToHexString(), c.ToHexString())
+		}
+		if l := black.Mix(white, 0.5, space).OKLCh().L; math.Abs(l-0.5) > 0.01 {
+			t.Errorf("expected lightness=%f, got=%f", 0.5, l)
+		}
+		if c := NoColor.Mix(white, 0.5, space); c != white {
+			t.Errorf("expected value=%s, got=%s", white.ToHexString(), c.ToHexString())
+		}
+	}
+
+	// red (hue 29) to magenta (hue 328) passes through pink, not green
+	red, magenta := NewColor("ff0000"), NewColor("ff00ff")
+	h := red.Mix(magenta, 0.5, ColorSpaceOKLCh).OKLCh().H
+	if h > 29 && h < 328 {
+		t.Errorf("expected hue on the shortest path, got=%f", h)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	dark := newTomorrowNight()
+	light, _ := Invert(dark)
+	light.SetScheme("Tomorrow")
+
+	if s := Interpolate(dark, light, 0, ColorSpaceOKLab); len(Diff(s, dark).Colors) > 0 {
+		t.Errorf("expected t=0 to return the colors of a, got diff %+v", Diff(s, dark))
+	}
+	for _, space := range []ColorSpace{ColorSpaceOKLab, ColorSpaceOKLCh} {
+		s := Interpolate(dark, light, 1, space)
+		if !Equal(s, light) {
+			t.Errorf("expected t=1 to return b, got diff %+v", Diff(s, light))
+		}
+	}
+
+	middle := Interpolate(dark, light, 0.4, ColorSpaceOKLCh)
+	if middle.Scheme() != "Tomorrow Night" || middle.Variant() != VariantDark {
+		t.Errorf("unexpected metadata scheme=%s variant=%s", middle.Scheme(), middle.Variant())
+	}
+	middle = Interpolate(dark, light, 0.6, ColorSpaceOKLCh)
+	if middle.Scheme() != "Tomorrow" || middle.Variant() != VariantLight {
+		t.Errorf("unexpected metadata scheme=%s variant=%s", middle.Scheme(), middle.Variant())
+	}
+}
+
+func TestInterpolateExtended(t *testing.T) {
+	a := newTomorrowNight()
+	b, _ := NewScheme("extended", "", ExtendedModeMaxColors)
+	for i := 0; i < ExtendedModeMaxColors; i++ {
+		b.SetColorAt(i, NewColor("808080"))
+	}
+	s := Interpolate(a, b, 0.5, ColorSpaceOKLab)
+	if s.CountColors() != ExtendedModeMaxColors {
+		t.Fatalf("expected value=%v, got=%v", ExtendedModeMaxColors, s.CountColors())
+	}
+	if s.GetColor("base1F") != NewColor("808080") {
+		t.Errorf("expected colors of only one scheme to be copied, got=%s", s.GetColor("base1F").ToHexString())
+	}
+}
+
+func TestInterpolateSteps(t *testing.T) {
+	dark := newTomorrowNight()
+	light, _ := Invert(dark)
+	steps := InterpolateSteps(dark, light, 3, ColorSpaceOKLab)
+	if len(steps) != 3 {
+		t.Fatalf("expected value=%v, got=%v", 3, len(steps))
+	}
+	previous := dark.ColorAt(0).OKLCh().L
+	for i, step := range steps {
+		l := step.ColorAt(0).OKLCh().L
+		if l <= previous {
+			t.Errorf("step %d: expected background to get lighter", i)
+		}
+		previous = l
+	}
+	if l := steps[1].ColorAt(0).OKLCh().L; math.Abs(l-(dark.ColorAt(0).OKLCh().L+light.ColorAt(0).OKLCh().L)/2) > 0.01 {
+		t.Errorf("expected the middle step to be half way, got lightness=%f", l)
+	}
+	if steps := InterpolateSteps(dark, light, 0, ColorSpaceOKLab); steps != nil {
+		t.Errorf("expected no steps, got=%d", len(steps))
+	}
+}
@@ -0,0 +1,382 @@
+package base16
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// LintSeverity is the severity of a lint issue.
+type LintSeverity int
+
+const (
+	// LintInfo marks hints which do not need to be fixed.
+	LintInfo LintSeverity = iota
+
+	// LintWarning marks likely quality problems.
+	LintWarning
+
+	// LintError marks problems which make a scheme unusable.
+	LintError
+)
+
+var lintSeverityNames = [...]string{"info", "warning", "error"}
+
+// String returns the name of the severity ("info", "warning" or "error").
+func (s LintSeverity) String() string {
+	if s < 0 || int(s) >= len(lintSeverityNames) {
+		return fmt.Sprintf("LintSeverity(%d)", int(s))
+	}
+	return lintSeverityNames[s]
+}
+
+// ParseLintSeverity returns the severity with the (case insensitive) name.
+func ParseLintSeverity(name string) (LintSeverity, error) {
+	for i, n := range lintSeverityNames {
+		if strings.EqualFold(n, name) {
+			return LintSeverity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid lint severity %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s LintSeverity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(lintSeverityNames) {
+		return nil, fmt.Errorf("invalid lint severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *LintSeverity) UnmarshalText(text []byte) error {
+	severity, err := ParseLintSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// Names of the default lint rules (see DefaultLintRules).
+const (
+	LintRuleUndefinedColor   = "undefined-color"
+	LintRuleRampMonotonic    = "ramp-monotonic"
+	LintRuleAccentSaturation = "accent-saturation"
+	LintRuleDuplicateAccent  = "duplicate-accent"
+	LintRuleVariantMismatch  = "variant-mismatch"
+	LintRuleContrast         = "contrast"
+)
+
+// LintIssue is a problem found by a lint rule.
+type LintIssue struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+
+	// Colors contains the names of the colors causing the issue (if any).
+	Colors  []string `json:"colors,omitempty"`
+	Message string   `json:"message"`
+}
+
+// LintRule is a named check of a scheme. Check returns the issues found in
+// the scheme, the linter sets Rule and Severity of the issues.
+type LintRule struct {
+	Name        string
+	Description string
+	Severity    LintSeverity
+	Check       func(scheme Scheme) []LintIssue
+}
+
+// LintReport holds the issues found by Linter.Lint.
+type LintReport struct {
+	Scheme string      `json:"scheme"`
+	Issues []LintIssue `json:"issues"`
+}
+
+// Count returns the number of issues with at least the given severity.
+func (r *LintReport) Count(severity LintSeverity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity >= severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors returns true if the report contains an issue with severity
+// LintError.
+func (r *LintReport) HasErrors() bool {
+	return r.Count(LintError) > 0
+}
+
+// Linter checks schemes with a set of rules. Rules can be enabled, disabled
+// and their severity can be overridden by name. The report of Lint can be
+// encoded as JSON.
+type Linter struct {
+	rules      []LintRule
+	disabled   map[string]bool
+	severities map[string]LintSeverity
+}
+
+// NewLinter returns a new linter with the given rules. Without arguments the
+// linter uses DefaultLintRules. Returns an error if a rule is invalid or
+// registered twice (see Register).
+func NewLinter(rules ...LintRule) (*Linter, error) {
+	if len(rules) == 0 {
+		rules = DefaultLintRules()
+	}
+	l := &Linter{
+		disabled:   make(map[string]bool),
+		severities: make(map[string]LintSeverity),
+	}
+	for _, rule := range rules {
+		if err := l.Register(rule); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Register adds rule to the linter. Returns an error if the name is empty,
+// Check is nil or a rule with the same name is registered.
+func (l *Linter) Register(rule LintRule) error {
+	if rule.Name == "" || rule.Check == nil {
+		return fmt.Errorf("lint rule must have a name and a check")
+	}
+	if l.rule(rule.Name) != nil {
+		return fmt.Errorf("lint rule %q is already registered", rule.Name)
+	}
+	l.rules = append(l.rules, rule)
+	return nil
+}
+
+// Rules returns all registered rules in the order of registration.
+func (l *Linter) Rules() []LintRule {
+	return append([]LintRule(nil), l.rules...)
+}
+
+// Enable enables the rule with the given name. Rules are enabled by default.
+func (l *Linter) Enable(name string) error {
+	if l.rule(name) == nil {
+		return fmt.Errorf("unknown lint rule %q", name)
+	}
+	delete(l.disabled, name)
+	return nil
+}
+
+// Disable disables the rule with the given name.
+func (l *Linter) Disable(name string) error {
+	if l.rule(name) == nil {
+		return fmt.Errorf("unknown lint rule %q", name)
+	}
+	l.disabled[name] = true
+	return nil
+}
+
+// Enabled returns true if the rule with the given name is registered and
+// enabled.
+func (l *Linter) Enabled(name string) bool {
+	return l.rule(name) != nil && !l.disabled[name]
+}
+
+// SetSeverity overrides the severity of the rule with the given name.
+func (l *Linter) SetSeverity(name string, severity LintSeverity) error {
+	if l.rule(name) == nil {
+		return fmt.Errorf("unknown lint rule %q", name)
+	}
+	l.severities[name] = severity
+	return nil
+}
+
+// Lint checks scheme with all enabled rules and returns the issues in the
+// order of the rules.
+func (l *Linter) Lint(scheme Scheme) *LintReport {
+	report := &LintReport{Scheme: scheme.Scheme(), Issues: []LintIssue{}}
+	for _, rule := range l.rules {
+		if l.disabled[rule.Name] {
+			continue
+		}
+		severity := rule.Severity
+		if s, ok := l.severities[rule.Name]; ok {
+			severity = s
+		}
+		for _, issue := range rule.Check(scheme) {
+			issue.Rule = rule.Name
+			issue.Severity = severity
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	return report
+}
+
+func (l *Linter) rule(name string) *LintRule {
+	for i := range l.rules {
+		if l.rules[i].Name == name {
+			return &l.rules[i]
+		}
+	}
+	return nil
+}
+
+// minAccentDistance is the OKLab distance below which two accents are
+// considered duplicates (about the smallest noticeable difference).
+const minAccentDistance = 0.02
+
+// DefaultLintRules returns the default lint rules:
+//
+//	undefined-color    (error)   a color of base00 - base0F is not defined
+//	ramp-monotonic     (warning) the lightness of base00 - base07 is not
+//	                             strictly monotonic
+//	accent-saturation  (warning) an accent is almost gray
+//	duplicate-accent   (warning) two accents are hardly distinguishable
+//	variant-mismatch   (error)   the variant metadata does not match the
+//	                             background lightness
+//	contrast           (warning) a pair fails the default Audit
+//
+// All rules except undefined-color skip undefined colors.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		{
+			Name:        LintRuleUndefinedColor,
+			Description: "all colors base00 - base0F are defined",
+			Severity:    LintError,
+			Check:       lintUndefinedColor,
+		},
+		{
+			Name:        LintRuleRampMonotonic,
+			Description: "the lightness of base00 - base07 is strictly monotonic",
+			Severity:    LintWarning,
+			Check:       lintRampMonotonic,
+		},
+		{
+			Name:        LintRuleAccentSaturation,
+			Description: "the accents base08 - base0F are saturated",
+			Severity:    LintWarning,
+			Check:       lintAccentSaturation,
+		},
+		{
+			Name:        LintRuleDuplicateAccent,
+			Description: "the accents base08 - base0F are distinguishable",
+			Severity:    LintWarning,
+			Check:       lintDuplicateAccent,
+		},
+		{
+			Name:        LintRuleVariantMismatch,
+			Description: "the variant metadata matches the background lightness",
+			Severity:    LintError,
+			Check:       lintVariantMismatch,
+		},
+		{
+			Name:        LintRuleContrast,
+			Description: "foreground colors pass the accessibility audit",
+			Severity:    LintWarning,
+			Check:       lintContrast,
+		},
+	}
+}
+
+func lintUndefinedColor(scheme Scheme) []LintIssue {
+	var issues []LintIssue
+	for i := 0; i < Base16DefaultColors; i++ {
+		if scheme.ColorAt(i) == NoColor {
+			name := ColorIndexName(i)
+			issues = append(issues, LintIssue{Colors: []string{name}, Message: fmt.Sprintf("color %s is not defined", name)})
+		}
+	}
+	return issues
+}
+
+func lintRampMonotonic(scheme Scheme) []LintIssue {
+	var ramp []int
+	for i := 0; i < 8; i++ {
+		if scheme.ColorAt(i) != NoColor {
+			ramp = append(ramp, i)
+		}
+	}
+	if len(ramp) < 2 {
+		return nil
+	}
+	first, last := scheme.ColorAt(ramp[0]).OKLCh().L, scheme.ColorAt(ramp[len(ramp)-1]).OKLCh().L
+	increasing := first < last
+
+	var issues []LintIssue
+	for k := 1; k < len(ramp); k++ {
+		prev, next := ColorIndexName(ramp[k-1]), ColorIndexName(ramp[k])
+		lPrev, lNext := scheme.ColorAt(ramp[k-1]).OKLCh().L, scheme.ColorAt(ramp[k]).OKLCh().L
+		if (increasing && lNext <= lPrev) || (!increasing && lNext >= lPrev) {
+			issues = append(issues, LintIssue{
+				Colors:  []string{prev, next},
+				Message: fmt.Sprintf("lightness of %s (%.3f) breaks the ramp after %s (%.3f)", next, lNext, prev, lPrev),
+			})
+		}
+	}
+	return issues
+}
+
+func lintAccentSaturation(scheme Scheme) []LintIssue {
+	var issues []LintIssue
+	for i := 8; i < Base16DefaultColors; i++ {
+		c := scheme.ColorAt(i)
+		if c == NoColor {
+			continue
+		}
+		if chroma := c.OKLCh().C; chroma < minAccentChroma {
+			name := ColorIndexName(i)
+			issues = append(issues, LintIssue{
+				Colors:  []string{name},
+				Message: fmt.Sprintf("accent %s is almost gray (chroma %.3f < %.3f)", name, chroma, minAccentChroma),
+			})
+		}
+	}
+	return issues
+}
+
+func lintDuplicateAccent(scheme Scheme) []LintIssue {
+	var issues []LintIssue
+	for i := 8; i < Base16DefaultColors; i++ {
+		for j := i + 1; j < Base16DefaultColors; j++ {
+			a, b := scheme.ColorAt(i), scheme.ColorAt(j)
+			if a == NoColor || b == NoColor {
+				continue
+			}
+			if distance := a.Distance(b); distance < minAccentDistance {
+				nameA, nameB := ColorIndexName(i), ColorIndexName(j)
+				issues = append(issues, LintIssue{
+					Colors:  []string{nameA, nameB},
+					Message: fmt.Sprintf("accents %s and %s are hardly distinguishable (distance %.3f)", nameA, nameB, distance),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func lintVariantMismatch(scheme Scheme) []LintIssue {
+	detected := backgroundVariant(scheme.ColorAt(0))
+	if scheme.Variant() == "" || detected == "" || strings.EqualFold(scheme.Variant(), detected) {
+		return nil
+	}
+	return []LintIssue{{
+		Colors:  []string{"base00"},
+		Message: fmt.Sprintf("variant is %q but the background is %s", scheme.Variant(), detected),
+	}}
+}
+
+func lintContrast(scheme Scheme) []LintIssue {
+	var issues []LintIssue
+	for _, failure := range Audit(scheme).Failures() {
+		if failure.Undefined {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Colors: []string{failure.Foreground, failure.Background},
+			Message: fmt.Sprintf(
+				"%s %s on %s has contrast %.2f (min %.2f) and APCA %.1f (min %.1f)",
+				failure.Role, failure.Foreground, failure.Background,
+				failure.Contrast, failure.MinContrast, math.Abs(failure.APCA), failure.MinAPCA,
+			),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,130 @@
+// +build !integration
+
+package base16
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func lintRules(report *LintReport) []string {
+	rules := []string{}
+	for _, issue := range report.Issues {
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+func TestLinter(t *testing.T) {
+	scheme := newTomorrowNight()
+	linter, err := NewLinter()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	linter.Disable(LintRuleContrast)
+	// the variant is compared case insensitive
+	for _, variant := range []string{VariantDark, "Dark"} {
+		scheme.SetVariant(variant)
+		if report := linter.Lint(scheme); len(report.Issues) != 0 {
+			t.Errorf("%s: expected no issues, got %+v", variant, report.Issues)
+		}
+	}
+
+	scheme.SetColor("base03", NewColor("1d1f21"))
+	scheme.SetColor("base0A", NewColor("cc6666"))
+	scheme.SetColor("base0C", NewColor("8a8a8a"))
+	scheme.SetColor("base0F", NoColor)
+	scheme.SetVariant(VariantLight)
+	report := linter.Lint(scheme)
+	expected := []string{
+		LintRuleUndefinedColor,
+		LintRuleRampMonotonic,
+		LintRuleAccentSaturation,
+		LintRuleDuplicateAccent,
+		LintRuleVariantMismatch,
+	}
+	if !reflect.DeepEqual(lintRules(report), expected) {
+		t.Errorf("expected value=%v, got=%v", expected, lintRules(report))
+	}
+	if !report.HasErrors() || report.Count(LintWarning) != 5 || report.Count(LintError) != 2 {
+		t.Errorf("unexpected counts warning=%d error=%d", report.Count(LintWarning), report.Count(LintError))
+	}
+	if issue := report.Issues[3]; !reflect.DeepEqual(issue.Colors, []string{"base08", "base0A"}) {
+		t.Errorf("unexpected colors %v", issue.Colors)
+	}
+
+	linter.SetSeverity(LintRuleVariantMismatch, LintInfo)
+	linter.Disable(LintRuleUndefinedColor)
+	if report := linter.Lint(scheme); report.HasErrors() || len(report.Issues) != 4 {
+		t.Errorf("expected 4 issues without errors, got %+v", report.Issues)
+	}
+	linter.Enable(LintRuleContrast)
+	if !linter.Enabled(LintRuleContrast) || linter.Enabled(LintRuleUndefinedColor) {
+		t.Errorf("unexpected enabled rules")
+	}
+	if err := linter.Disable("unknown"); err == nil {
+		t.Errorf("expected error for unknown rule")
+	}
+}
+
+func TestLinterCustomRule(t *testing.T) {
+	rule := LintRule{
+		Name:     "named",
+		Severity: LintError,
+		Check: func(scheme Scheme) []LintIssue {
+			if scheme.Scheme() == "" {
+				return []LintIssue{{Message: "scheme has no name"}}
+			}
+			return nil
+		},
+	}
+	linter, err := NewLinter(rule)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := linter.Register(rule); err == nil {
+		t.Errorf("expected error for duplicate rule")
+	}
+	if err := linter.Register(LintRule{Name: "no-check"}); err == nil {
+		t.Errorf("expected error for rule without check")
+	}
+	if _, err := NewLinter(rule, rule); err == nil {
+		t.Errorf("expected error for duplicate rule")
+	}
+	if _, err := NewLinter(LintRule{Check: rule.Check}); err == nil {
+		t.Errorf("expected error for rule without name")
+	}
+	if len(linter.Rules()) != 1 {
+		t.Errorf("expected value=%v, got=%v", 1, len(linter.Rules()))
+	}
+
+	scheme, _ := NewScheme("", "")
+	report := linter.Lint(scheme)
+	if len(report.Issues) != 1 || report.Issues[0].Rule != "named" || report.Issues[0].Severity != LintError {
+		t.Errorf("unexpected issues %+v", report.Issues)
+	}
+}
+
+func TestLintReportJSON(t *testing.T) {
+	report := &LintReport{
+		Scheme: "test",
+		Issues: []LintIssue{{Rule: LintRuleDuplicateAccent, Severity: LintWarning, Colors: []string{"base08", "base09"}, Message: "duplicate"}},
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `{"scheme":"test","issues":[{"rule":"duplicate-accent","severity":"warning","colors":["base08","base09"],"message":"duplicate"}]}`
+	if string(data) != expected {
+		t.Errorf("expected value=%v, got=%v", expected, string(data))
+	}
+
+	var decoded LintReport
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(&decoded, report) {
+		t.Errorf("expected round trip, got %+v err=%v", decoded, err)
+	}
+	if _, err := ParseLintSeverity("fatal"); err == nil {
+		t.Errorf("expected error for invalid severity")
+	}
+}
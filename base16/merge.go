@@ -0,0 +1,135 @@
+package base16
+
+// MergeStrategy defines how Merge resolves conflicting changes.
+type MergeStrategy int
+
+const (
+	// MergeKeepOurs resolves conflicts using the value of ours.
+	MergeKeepOurs MergeStrategy = iota
+
+	// MergeKeepTheirs resolves conflicts using the value of theirs.
+	MergeKeepTheirs
+)
+
+// ColorConflict describes a color which was changed differently by ours and
+// theirs.
+type ColorConflict struct {
+	Name   string
+	Base   Color
+	Ours   Color
+	Theirs Color
+}
+
+// MetadataConflict describes a metadata field which was changed differently by
+// ours and theirs.
+type MetadataConflict struct {
+	Field  string
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// MergeResult holds the result of a three-way merge.
+type MergeResult struct {
+	// Scheme is the merged scheme. Conflicts are resolved according to the
+	// merge strategy.
+	Scheme Scheme
+
+	// ColorConflicts contains all conflicting colors sorted by color name.
+	ColorConflicts []ColorConflict
+
+	// MetadataConflicts contains all conflicting metadata fields.
+	MetadataConflicts []MetadataConflict
+}
+
+// HasConflicts returns true if the merge produced any conflicts.
+func (r *MergeResult) HasConflicts() bool {
+	return len(r.ColorConflicts) > 0 || len(r.MetadataConflicts) > 0
+}
+
+// Merge performs a three-way merge of the schemes ours and theirs, which were
+// both derived from base. Every color and metadata field is merged
+// independently: a change made by only one side is applied, identical changes
+// are applied once and different changes to the same field are reported as
+// conflict. Conflicts are resolved using the optional strategy (defaults to
+// MergeKeepOurs). The resulting scheme holds as many colors as the larger of
+// ours and theirs.
+func Merge(base Scheme, ours Scheme, theirs Scheme, strategy ...MergeStrategy) *MergeResult {
+	keepTheirs := len(strategy) == 1 && strategy[0] == MergeKeepTheirs
+	result := &MergeResult{}
+
+	metadata := make(map[string]string, 3)
+	fields := []struct {
+		name  string
+		value func(Scheme) string
+	}{
+		{FieldScheme, Scheme.Scheme},
+		{FieldAuthor, Scheme.Author},
+		{FieldVariant, Scheme.Variant},
+	}
+	for _, field := range fields {
+		b, o, t := field.value(base), field.value(ours), field.value(theirs)
+		merged, conflict := mergeString(b, o, t, keepTheirs)
+		if conflict {
+			result.MetadataConflicts = append(result.MetadataConflicts, MetadataConflict{
+				Field: field.name, Base: b, Ours: o, Theirs: t,
+			})
+		}
+		metadata[field.name] = merged
+	}
+
+	count := ours.CountColors()
+	if theirs.CountColors() > count {
+		count = theirs.CountColors()
+	}
+	// NewScheme only fails for invalid color counts, ours and theirs are
+	// valid schemes.
+	scheme, _ := NewScheme(metadata[FieldScheme], metadata[FieldAuthor], count)
+	scheme.SetVariant(metadata[FieldVariant])
+
+	for _, colorname := range scheme.GetColorNames() {
+		b, o, t := schemeColor(base, colorname), schemeColor(ours, colorname), schemeColor(theirs, colorname)
+		merged, conflict := mergeColor(b, o, t, keepTheirs)
+		if conflict {
+			result.ColorConflicts = append(result.ColorConflicts, ColorConflict{
+				Name: colorname, Base: b, Ours: o, Theirs: t,
+			})
+		}
+		scheme.SetColor(colorname, merged)
+	}
+
+	result.Scheme = scheme
+	return result
+}
+
+// mergeString merges a single metadata value. The second return value is true
+// if ours and theirs changed the value differently.
+func mergeString(base string, ours string, theirs string, keepTheirs bool) (string, bool) {
+	switch {
+	case ours == theirs:
+		return ours, false
+	case ours == base:
+		return theirs, false
+	case theirs == base:
+		return ours, false
+	case keepTheirs:
+		return theirs, true
+	}
+	return ours, true
+}
+
+// mergeColor merges a single color. The second return value is true if ours
+// and theirs changed the color differently.
+func mergeColor(base Color, ours Color, theirs Color, keepTheirs bool) (Color, bool) {
+	switch {
+	case ours == theirs:
+		return ours, false
+	case ours == base:
+		return theirs, false
+	case theirs == base:
+		return ours, false
+	case keepTheirs:
+		return theirs, true
+	}
+	return ours, true
+}
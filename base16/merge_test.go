@@ -0,0 +1,99 @@
+// +build !integration
+
+package base16
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newMergeTestSchemes() (Scheme, Scheme, Scheme) {
+	base, _ := NewScheme("Default Dark", "Chris Kempson")
+	for _, colorname := range base.GetColorNames() {
+		base.SetColor(colorname, NewColor("181818"))
+	}
+	return base, Clone(base), Clone(base)
+}
+
+func TestMerge(t *testing.T) {
+	base, ours, theirs := newMergeTestSchemes()
+
+	ours.SetColor("base08", NewColor("ff0000"))
+	ours.SetAuthor("fred")
+	theirs.SetColor("base0A", NewColor("00ff00"))
+	theirs.SetScheme("Default Dark (Updated)")
+	ours.SetColor("base0B", NewColor("0000ff"))
+	theirs.SetColor("base0B", NewColor("0000ff"))
+
+	result := Merge(base, ours, theirs)
+	if result.HasConflicts() {
+		t.Fatalf("expected no conflicts, got=%v %v", result.ColorConflicts, result.MetadataConflicts)
+	}
+
+	merged := result.Scheme
+	if merged.Scheme() != "Default Dark (Updated)" || merged.Author() != "fred" {
+		t.Errorf("unexpected metadata scheme=%s author=%s", merged.Scheme(), merged.Author())
+	}
+	expectColors := map[string]Color{
+		"base00": NewColor("181818"),
+		"base08": NewColor("ff0000"),
+		"base0A": NewColor("00ff00"),
+		"base0B": NewColor("0000ff"),
+	}
+	for colorname, expectColor := range expectColors {
+		if got := merged.GetColor(colorname); got != expectColor {
+			t.Errorf("%s: expected value=%s, got=%s", colorname, expectColor.ToHexString(), got.ToHexString())
+		}
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	base, ours, theirs := newMergeTestSchemes()
+
+	ours.SetColor("base08", NewColor("ff0000"))
+	theirs.SetColor("base08", NewColor("00ff00"))
+	ours.SetVariant(VariantDark)
+	theirs.SetVariant(VariantLight)
+
+	result := Merge(base, ours, theirs)
+	expectColorConflicts := []ColorConflict{
+		{Name: "base08", Base: NewColor("181818"), Ours: NewColor("ff0000"), Theirs: NewColor("00ff00")},
+	}
+	if !reflect.DeepEqual(expectColorConflicts, result.ColorConflicts) {
+		t.Errorf("expected value=%v, got=%v", expectColorConflicts, result.ColorConflicts)
+	}
+	expectMetadataConflicts := []MetadataConflict{
+		{Field: FieldVariant, Base: "", Ours: "dark", Theirs: "light"},
+	}
+	if !reflect.DeepEqual(expectMetadataConflicts, result.MetadataConflicts) {
+		t.Errorf("expected value=%v, got=%v", expectMetadataConflicts, result.MetadataConflicts)
+	}
+	if result.Scheme.GetColor("base08") != NewColor("ff0000") || result.Scheme.Variant() != "dark" {
+		t.Errorf("expected conflicts to be resolved using ours")
+	}
+
+	result = Merge(base, ours, theirs, MergeKeepTheirs)
+	if result.Scheme.GetColor("base08") != NewColor("00ff00") || result.Scheme.Variant() != "light" {
+		t.Errorf("expected conflicts to be resolved using theirs")
+	}
+}
+
+func TestMergeExtended(t *testing.T) {
+	base, ours, _ := newMergeTestSchemes()
+	theirs, _ := NewScheme(base.Scheme(), base.Author(), 18)
+	for _, colorname := range base.GetColorNames() {
+		theirs.SetColor(colorname, base.GetColor(colorname))
+	}
+	theirs.SetColor("base11", NewColor("00ffff"))
+
+	result := Merge(base, ours, theirs)
+	if result.HasConflicts() {
+		t.Fatalf("expected no conflicts, got=%v", result.ColorConflicts)
+	}
+	if result.Scheme.CountColors() != 18 {
+		t.Errorf("expected value=%d, got=%d", 18, result.Scheme.CountColors())
+	}
+	if result.Scheme.GetColor("base11") != NewColor("00ffff") {
+		t.Errorf("expected value=%s, got=%s", "00ffff", result.Scheme.GetColor("base11").ToHexString())
+	}
+}
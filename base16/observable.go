@@ -0,0 +1,248 @@
+package base16
+
+import (
+	"sync"
+)
+
+// SchemeChange describes a single change of an ObservableScheme.
+type SchemeChange struct {
+	// Name is the color name (e.g. "base0A") for color changes or the metadata
+	// field (see FieldScheme, FieldAuthor and FieldVariant) for metadata
+	// changes.
+	Name string
+
+	// OldColor and NewColor hold the colors of a color change.
+	OldColor Color
+	NewColor Color
+
+	// OldValue and NewValue hold the values of a metadata change.
+	OldValue string
+	NewValue string
+}
+
+// IsColor returns true if the change is a color change.
+func (c SchemeChange) IsColor() bool {
+	return ColorNameIndex(c.Name) >= 0
+}
+
+// subscriber is a registered change callback.
+type subscriber struct {
+	id int
+	f  func(SchemeChange)
+}
+
+// ObservableScheme implements the Scheme interface by wrapping another Scheme
+// and notifying subscribers about every change. Setting a value equal to the
+// current value does not emit a change. Subscribing and unsubscribing is safe
+// for concurrent use, access to the wrapped scheme is not synchronized (wrap a
+// SyncScheme if needed).
+type ObservableScheme struct {
+	scheme Scheme
+
+	mu          sync.Mutex
+	nextID      int
+	subscribers []subscriber
+}
+
+// NewObservableScheme returns an observable scheme wrapping scheme.
+func NewObservableScheme(scheme Scheme) *ObservableScheme {
+	return &ObservableScheme{scheme: scheme}
+}
+
+// Subscribe registers f to be called synchronously after every change. f may
+// call unsubscribe but must not change the scheme. Calling the returned
+// unsubscribe function more than once has no effect. Subscribe panics if f is
+// nil.
+func (s *ObservableScheme) Subscribe(f func(change SchemeChange)) (unsubscribe func()) {
+	if f == nil {
+		panic("base16: nil subscriber")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID++
+	s.subscribers = append(s.subscribers, subscriber{id: id, f: f})
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, sub := range s.subscribers {
+			if sub.id == id {
+				s.subscribers = append(s.subscribers[:i:i], s.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SubscribeChan returns a channel receiving all changes in order. Changes are
+// queued without limit, slow receivers never block changes to the scheme. The
+// returned unsubscribe function stops the delivery and closes the channel,
+// changes not yet received may be discarded. It must be called to release the
+// delivery goroutine.
+func (s *ObservableScheme) SubscribeChan() (changes <-chan SchemeChange, unsubscribe func()) {
+	q := &changeQueue{
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		out:    make(chan SchemeChange),
+	}
+	stop := s.Subscribe(q.push)
+	go q.run()
+
+	var once sync.Once
+	return q.out, func() {
+		once.Do(func() {
+			stop()
+			close(q.done)
+		})
+	}
+}
+
+// Author returns the author of the scheme
+func (s *ObservableScheme) Author() string {
+	return s.scheme.Author()
+}
+
+// SetAuthor sets the author name of the scheme
+func (s *ObservableScheme) SetAuthor(name string) {
+	old := s.scheme.Author()
+	s.scheme.SetAuthor(name)
+	s.notifyMetadata(FieldAuthor, old, name)
+}
+
+// Scheme returns the scheme identifier (name)
+func (s *ObservableScheme) Scheme() string {
+	return s.scheme.Scheme()
+}
+
+// SetScheme sets the scheme identifier of the scheme
+func (s *ObservableScheme) SetScheme(name string) {
+	old := s.scheme.Scheme()
+	s.scheme.SetScheme(name)
+	s.notifyMetadata(FieldScheme, old, name)
+}
+
+// Variant returns the scheme variant (e.g. "dark" or "light")
+func (s *ObservableScheme) Variant() string {
+	return s.scheme.Variant()
+}
+
+// SetVariant sets the variant of the scheme
+func (s *ObservableScheme) SetVariant(variant string) {
+	old := s.scheme.Variant()
+	s.scheme.SetVariant(variant)
+	s.notifyMetadata(FieldVariant, old, variant)
+}
+
+// CountColors returns the number of colors
+func (s *ObservableScheme) CountColors() int {
+	return s.scheme.CountColors()
+}
+
+// GetColor returns the color specified by colorname
+func (s *ObservableScheme) GetColor(colorname string) Color {
+	return s.scheme.GetColor(colorname)
+}
+
+// GetColorNames returns a sorted string slice of all color names
+func (s *ObservableScheme) GetColorNames() []string {
+	return s.scheme.GetColorNames()
+}
+
+// ExtendedModeOn returns the extended mode flag
+func (s *ObservableScheme) ExtendedModeOn() bool {
+	return s.scheme.ExtendedModeOn()
+}
+
+// SetColor sets the color c for color name. Invalid color names do not emit a
+// change.
+func (s *ObservableScheme) SetColor(colorname string, c Color) error {
+	old := s.scheme.GetColor(colorname)
+	if err := s.scheme.SetColor(colorname, c); err != nil {
+		return err
+	}
+	if old != c {
+		s.notify(SchemeChange{Name: canonicalColorName(colorname), OldColor: old, NewColor: c})
+	}
+	return nil
+}
+
+// ColorAt returns the color at index (zero based)
+func (s *ObservableScheme) ColorAt(index int) Color {
+	return s.scheme.ColorAt(index)
+}
+
+// SetColorAt sets the color c at index (zero based)
+func (s *ObservableScheme) SetColorAt(index int, c Color) error {
+	if index < 0 || index >= s.scheme.CountColors() {
+		return s.scheme.SetColorAt(index, c)
+	}
+	return s.SetColor(ColorIndexName(index), c)
+}
+
+// Colors returns all colors with their names ordered by index
+func (s *ObservableScheme) Colors() []NamedColor {
+	return s.scheme.Colors()
+}
+
+func (s *ObservableScheme) notifyMetadata(field string, old string, value string) {
+	if old != value {
+		s.notify(SchemeChange{Name: field, OldColor: NoColor, NewColor: NoColor, OldValue: old, NewValue: value})
+	}
+}
+
+func (s *ObservableScheme) notify(change SchemeChange) {
+	// callbacks are called without holding the lock, so that they may
+	// unsubscribe themselves
+	s.mu.Lock()
+	subscribers := append([]subscriber(nil), s.subscribers...)
+	s.mu.Unlock()
+
+	for _, sub := range subscribers {
+		sub.f(change)
+	}
+}
+
+// changeQueue delivers changes to a channel without blocking the sender.
+type changeQueue struct {
+	mu     sync.Mutex
+	queue  []SchemeChange
+	notify chan struct{}
+	done   chan struct{}
+	out    chan SchemeChange
+}
+
+func (q *changeQueue) push(change SchemeChange) {
+	q.mu.Lock()
+	q.queue = append(q.queue, change)
+	q.mu.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *changeQueue) run() {
+	defer close(q.out)
+	for {
+		q.mu.Lock()
+		if len(q.queue) == 0 {
+			q.mu.Unlock()
+			select {
+			case <-q.notify:
+				continue
+			case <-q.done:
+				return
+			}
+		}
+		change := q.queue[0]
+		q.queue = q.queue[1:]
+		q.mu.Unlock()
+
+		select {
+		case q.out <- change:
+		case <-q.done:
+			return
+		}
+	}
+}
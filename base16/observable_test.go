@@ -0,0 +1,146 @@
+// +build !integration
+
+package base16
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestObservableSchemeSubscribe(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	var scheme Scheme = NewObservableScheme(data)
+
+	var got []SchemeChange
+	unsubscribe := scheme.(*ObservableScheme).Subscribe(func(change SchemeChange) {
+		got = append(got, change)
+	})
+
+	scheme.SetColor("base00", NewColor("181818"))
+	scheme.SetColor("base00", NewColor("181818"))
+	scheme.SetAuthor("fred")
+	scheme.SetVariant(VariantDark)
+	unsubscribe()
+	unsubscribe()
+	scheme.SetScheme("changed")
+
+	expect := []SchemeChange{
+		{Name: "base00", OldColor: NoColor, NewColor: NewColor("181818")},
+		{Name: FieldAuthor, OldColor: NoColor, NewColor: NoColor, OldValue: "nobody", NewValue: "fred"},
+		{Name: FieldVariant, OldColor: NoColor, NewColor: NoColor, OldValue: "", NewValue: "dark"},
+	}
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected value=%v, got=%v", expect, got)
+	}
+	if !got[0].IsColor() || got[1].IsColor() {
+		t.Errorf("unexpected IsColor() result")
+	}
+	if scheme.Scheme() != "changed" {
+		t.Errorf("expected value=%s, got=%s", "changed", scheme.Scheme())
+	}
+}
+
+func TestObservableSchemeUnsubscribeInCallback(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewObservableScheme(data)
+
+	count := 0
+	var unsubscribe func()
+	unsubscribe = scheme.Subscribe(func(change SchemeChange) {
+		count++
+		unsubscribe()
+	})
+	scheme.SetAuthor("fred")
+	scheme.SetAuthor("barney")
+
+	if count != 1 {
+		t.Errorf("expected value=%d, got=%d", 1, count)
+	}
+}
+
+func TestObservableSchemeSubscribeNil(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewObservableScheme(data)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected Subscribe to panic")
+			}
+		}()
+		scheme.Subscribe(nil)
+	}()
+	if err := scheme.SetColor("base00", NewColor("181818")); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestObservableSchemeSubscribeChan(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewObservableScheme(data)
+	goroutines := runtime.NumGoroutine()
+
+	changes, unsubscribe := scheme.SubscribeChan()
+	for i := 0; i < Base16DefaultColors; i++ {
+		scheme.SetColor(ColorIndexName(i), NewColorRGB(uint8(i), 0, 0))
+	}
+	for i := 0; i < Base16DefaultColors; i++ {
+		change := <-changes
+		if change.Name != ColorIndexName(i) || change.NewColor != NewColorRGB(uint8(i), 0, 0) {
+			t.Errorf("unexpected change %v", change)
+		}
+	}
+
+	// the channel is closed after unsubscribe
+	scheme.SetAuthor("fred")
+	unsubscribe()
+	unsubscribe()
+	for range changes {
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if runtime.NumGoroutine() > goroutines {
+		t.Errorf("expected delivery goroutine to exit")
+	}
+}
+
+func TestObservableSchemeInvalidColor(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewObservableScheme(data)
+
+	var got []SchemeChange
+	scheme.Subscribe(func(change SchemeChange) {
+		got = append(got, change)
+	})
+	if err := scheme.SetColor("base99", NewColor("ff0000")); err == nil {
+		t.Errorf("expected error not nil")
+	}
+	scheme.SetColor("base0a", NewColor("ff0000"))
+
+	if len(got) != 1 || got[0].Name != "base0A" {
+		t.Errorf("expected a single change of base0A, got=%v", got)
+	}
+}
+
+func TestObservableSchemeSetColorAt(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewObservableScheme(data)
+
+	var got []SchemeChange
+	scheme.Subscribe(func(change SchemeChange) {
+		got = append(got, change)
+	})
+	scheme.SetColorAt(10, NewColor("f7ca88"))
+	if err := scheme.SetColorAt(16, NewColor("f7ca88")); err == nil {
+		t.Errorf("expected error not nil")
+	}
+
+	if len(got) != 1 || got[0].Name != "base0A" || got[0].NewColor != NewColor("f7ca88") {
+		t.Errorf("expected a single change of base0A, got=%v", got)
+	}
+}
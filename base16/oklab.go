@@ -0,0 +1,123 @@
+package base16
+
+import (
+	"math"
+)
+
+// OKLab represents a color in the perceptual OKLab color space (see
+// https://bottosson.github.io/posts/oklab/). L is the perceived lightness in
+// the range [0, 1], A and B are the green/red and blue/yellow axes.
+type OKLab struct {
+	L, A, B float64
+}
+
+// OKLab converts the color to the OKLab color space.
+func (c Color) OKLab() OKLab {
+	r8, g8, b8 := c.RGB()
+	r, g, b := linearize(r8), linearize(g8), linearize(b8)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// Color converts lab to the nearest sRGB color. Out of gamut values are
+// clipped.
+func (lab OKLab) Color() Color {
+	r, g, b := lab.linearRGB()
+	return NewColorRGB(delinearize(r), delinearize(g), delinearize(b))
+}
+
+// Distance returns the euclidean distance between c and other in the OKLab
+// color space. A distance of about 0.02 is the smallest noticeable difference,
+// the distance between black and white is 1.
+func (c Color) Distance(other Color) float64 {
+	a, b := c.OKLab(), other.OKLab()
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// delinearize converts a linear light component to an 8 bit sRGB value.
+func delinearize(v float64) uint8 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+// OKLCh represents a color in the cylindrical form of the OKLab color space.
+// L is the perceived lightness in the range [0, 1], C the chroma and H the hue
+// angle in degrees [0, 360).
+type OKLCh struct {
+	L, C, H float64
+}
+
+// OKLCh converts the color to the OKLCh color space.
+func (c Color) OKLCh() OKLCh {
+	return c.OKLab().LCh()
+}
+
+// LCh converts lab to the OKLCh color space.
+func (lab OKLab) LCh() OKLCh {
+	h := math.Atan2(lab.B, lab.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCh{L: lab.L, C: math.Hypot(lab.A, lab.B), H: h}
+}
+
+// Lab converts lch to the OKLab color space.
+func (lch OKLCh) Lab() OKLab {
+	h := lch.H * math.Pi / 180
+	return OKLab{L: lch.L, A: lch.C * math.Cos(h), B: lch.C * math.Sin(h)}
+}
+
+// Color converts lch to the nearest sRGB color. Out of gamut values are mapped
+// into the sRGB gamut by reducing the chroma while preserving lightness and
+// hue.
+func (lch OKLCh) Color() Color {
+	lch.L = math.Max(0, math.Min(1, lch.L))
+	if lch.Lab().InGamut() {
+		return lch.Lab().Color()
+	}
+	low, high := 0.0, lch.C
+	for high-low > 1e-4 {
+		lch.C = (low + high) / 2
+		if lch.Lab().InGamut() {
+			low = lch.C
+		} else {
+			high = lch.C
+		}
+	}
+	lch.C = low
+	return lch.Lab().Color()
+}
+
+// InGamut returns true if lab can be represented as sRGB color without
+// clipping.
+func (lab OKLab) InGamut() bool {
+	r, g, b := lab.linearRGB()
+	const eps = 1e-6
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+}
+
+// linearRGB converts lab to linear sRGB components.
+func (lab OKLab) linearRGB() (r, g, b float64) {
+	l := lab.L + 0.3963377774*lab.A + 0.2158037573*lab.B
+	m := lab.L - 0.1055613458*lab.A - 0.0638541728*lab.B
+	s := lab.L - 0.0894841775*lab.A - 1.2914855480*lab.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r = 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g = -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b = -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+	return r, g, b
+}
@@ -0,0 +1,81 @@
+// +build !integration
+
+package base16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOKLab(t *testing.T) {
+	testCases := []struct {
+		color  Color
+		expect OKLab
+	}{
+		{NewColor("000000"), OKLab{0, 0, 0}},
+		{NewColor("ffffff"), OKLab{1, 0, 0}},
+		{NewColor("ff0000"), OKLab{0.627955, 0.224863, 0.125846}},
+		{NewColor("0000ff"), OKLab{0.452014, -0.032457, -0.311528}},
+	}
+	for _, table := range testCases {
+		got := table.color.OKLab()
+		if math.Abs(got.L-table.expect.L) > 1e-4 ||
+			math.Abs(got.A-table.expect.A) > 1e-4 ||
+			math.Abs(got.B-table.expect.B) > 1e-4 {
+			t.Errorf("color=%s: expected value=%v, got=%v", table.color.ToHexString(), table.expect, got)
+		}
+	}
+}
+
+func TestOKLabColor(t *testing.T) {
+	for _, hex := range []string{"000000", "ffffff", "181818", "ab4642", "7cafc2", "f7ca88"} {
+		color := NewColor(hex)
+		got := color.OKLab().Color()
+		if got != color {
+			t.Errorf("expected value=%s, got=%s", hex, got.ToHexString())
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	black := NewColor("000000")
+	white := NewColor("ffffff")
+
+	if got := black.Distance(white); math.Abs(got-1) > 1e-4 {
+		t.Errorf("expected value=%f, got=%f", 1.0, got)
+	}
+	if got := black.Distance(black); got != 0 {
+		t.Errorf("expected value=%f, got=%f", 0.0, got)
+	}
+	near := NewColor("1d1f21").Distance(NewColor("1c1e20"))
+	far := NewColor("1d1f21").Distance(NewColor("fdf6e3"))
+	if near >= far {
+		t.Errorf("expected near=%f < far=%f", near, far)
+	}
+}
+
+func TestOKLCh(t *testing.T) {
+	lch := NewColor("ff0000").OKLCh()
+	if math.Abs(lch.L-0.627955) > 1e-4 || math.Abs(lch.C-0.257683) > 1e-4 || math.Abs(lch.H-29.2339) > 1e-2 {
+		t.Errorf("expected value=%v, got=%v", OKLCh{0.627955, 0.257683, 29.2339}, lch)
+	}
+	for _, hex := range []string{"000000", "ffffff", "1d1f21", "cc6666", "81a2be", "b5bd68"} {
+		if got := NewColor(hex).OKLCh().Color(); got != NewColor(hex) {
+			t.Errorf("expected value=%s, got=%s", hex, got.ToHexString())
+		}
+	}
+}
+
+func TestOKLChGamutMapping(t *testing.T) {
+	lch := OKLCh{L: 0.7, C: 0.4, H: 140}
+	if lch.Lab().InGamut() {
+		t.Fatalf("expected %v to be out of gamut", lch)
+	}
+	got := lch.Color().OKLCh()
+	if math.Abs(got.L-lch.L) > 0.01 || math.Abs(hueDelta(got.H, lch.H)) > 2 {
+		t.Errorf("expected lightness and hue to be preserved, got=%v", got)
+	}
+	if got.C >= lch.C {
+		t.Errorf("expected chroma to be reduced, got=%v", got)
+	}
+}
@@ -0,0 +1,165 @@
+package base16
+
+import (
+	"sort"
+	"strings"
+)
+
+// Query filters and sorts a collection of schemes. Filters are added using the
+// chainable filter methods and are combined with a logical AND. A Query can be
+// run multiple times against different collections.
+type Query struct {
+	filters []func(Scheme) bool
+	targets []colorTarget
+	limit   int
+}
+
+// QueryResult is a single match of a Query.
+type QueryResult struct {
+	// Scheme is the matching scheme.
+	Scheme Scheme
+
+	// Distance is the sum of the color distances of all ColorNear filters
+	// (zero if the query has no color filters).
+	Distance float64
+}
+
+// colorTarget holds the arguments of a ColorNear filter.
+type colorTarget struct {
+	colorname   string
+	color       Color
+	maxDistance float64
+}
+
+// NewQuery returns an empty query matching all schemes.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Text matches schemes whose name or author contains every word of text. The
+// comparison is case insensitive.
+func (q *Query) Text(text string) *Query {
+	words := strings.Fields(strings.ToLower(text))
+	return q.Where(func(scheme Scheme) bool {
+		haystack := strings.ToLower(scheme.Scheme() + " " + scheme.Author())
+		for _, word := range words {
+			if !strings.Contains(haystack, word) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// NameContains matches schemes whose name contains name. The comparison is
+// case insensitive.
+func (q *Query) NameContains(name string) *Query {
+	name = strings.ToLower(name)
+	return q.Where(func(scheme Scheme) bool {
+		return strings.Contains(strings.ToLower(scheme.Scheme()), name)
+	})
+}
+
+// Author matches schemes whose author contains author. The comparison is case
+// insensitive.
+func (q *Query) Author(author string) *Query {
+	author = strings.ToLower(author)
+	return q.Where(func(scheme Scheme) bool {
+		return strings.Contains(strings.ToLower(scheme.Author()), author)
+	})
+}
+
+// Variant matches schemes of the given variant (see DetectVariant).
+func (q *Query) Variant(variant string) *Query {
+	return q.Where(func(scheme Scheme) bool {
+		return strings.EqualFold(DetectVariant(scheme), variant)
+	})
+}
+
+// Dark matches schemes of the dark variant.
+func (q *Query) Dark() *Query {
+	return q.Variant(VariantDark)
+}
+
+// Light matches schemes of the light variant.
+func (q *Query) Light() *Query {
+	return q.Variant(VariantLight)
+}
+
+// ColorNear matches schemes where the color given by colorname is at most
+// maxDistance (see Color.Distance) away from c. Results of queries with color
+// filters are sorted by distance.
+func (q *Query) ColorNear(colorname string, c Color, maxDistance float64) *Query {
+	q.targets = append(q.targets, colorTarget{colorname: colorname, color: c, maxDistance: maxDistance})
+	return q
+}
+
+// Where matches schemes for which the predicate f returns true.
+func (q *Query) Where(f func(Scheme) bool) *Query {
+	q.filters = append(q.filters, f)
+	return q
+}
+
+// Limit limits the number of results to n. A value <= 0 disables the limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Run returns all schemes matching the query. Results are sorted by distance
+// (see ColorNear), then by scheme name and author.
+func (q *Query) Run(schemes []Scheme) []QueryResult {
+	results := make([]QueryResult, 0, len(schemes))
+	for _, scheme := range schemes {
+		if result, ok := q.match(scheme); ok {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Scheme.Scheme() != b.Scheme.Scheme() {
+			return a.Scheme.Scheme() < b.Scheme.Scheme()
+		}
+		return a.Scheme.Author() < b.Scheme.Author()
+	})
+
+	if q.limit > 0 && len(results) > q.limit {
+		results = results[:q.limit]
+	}
+	return results
+}
+
+// Schemes returns the schemes of Run.
+func (q *Query) Schemes(schemes []Scheme) []Scheme {
+	results := q.Run(schemes)
+	matches := make([]Scheme, 0, len(results))
+	for _, result := range results {
+		matches = append(matches, result.Scheme)
+	}
+	return matches
+}
+
+func (q *Query) match(scheme Scheme) (QueryResult, bool) {
+	result := QueryResult{Scheme: scheme}
+	for _, f := range q.filters {
+		if !f(scheme) {
+			return result, false
+		}
+	}
+	for _, target := range q.targets {
+		c := schemeColor(scheme, target.colorname)
+		if c == NoColor {
+			return result, false
+		}
+		distance := c.Distance(target.color)
+		if distance > target.maxDistance {
+			return result, false
+		}
+		result.Distance += distance
+	}
+	return result, true
+}
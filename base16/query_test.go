@@ -0,0 +1,68 @@
+// +build !integration
+
+package base16
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testQuerySchemes() []Scheme {
+	return []Scheme{
+		newTestScheme("Tomorrow Night", "Chris Kempson", "1d1f21"),
+		newTestScheme("Default Dark", "Chris Kempson", "181818"),
+		newTestScheme("Solarized Light", "Ethan Schoonover", "fdf6e3"),
+		newTestScheme("Tomorrow", "Chris Kempson", "ffffff"),
+		newTestScheme("Ocean", "Chris Kempson", "2b303b"),
+	}
+}
+
+func schemeNames(results []QueryResult) []string {
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Scheme.Scheme())
+	}
+	return names
+}
+
+func TestQuery(t *testing.T) {
+	schemes := testQuerySchemes()
+
+	testCases := map[string]struct {
+		query  *Query
+		expect []string
+	}{
+		"all":           {query: NewQuery(), expect: []string{"Default Dark", "Ocean", "Solarized Light", "Tomorrow", "Tomorrow Night"}},
+		"name":          {query: NewQuery().NameContains("tomorrow"), expect: []string{"Tomorrow", "Tomorrow Night"}},
+		"author":        {query: NewQuery().Author("schoonover"), expect: []string{"Solarized Light"}},
+		"text":          {query: NewQuery().Text("kempson night"), expect: []string{"Tomorrow Night"}},
+		"dark":          {query: NewQuery().Dark(), expect: []string{"Default Dark", "Ocean", "Tomorrow Night"}},
+		"light":         {query: NewQuery().Light(), expect: []string{"Solarized Light", "Tomorrow"}},
+		"limit":         {query: NewQuery().Dark().Limit(1), expect: []string{"Default Dark"}},
+		"invalid color": {query: NewQuery().ColorNear("base10", NewColor("000000"), 1), expect: []string{}},
+		"where": {
+			query:  NewQuery().Where(func(s Scheme) bool { return len(s.Scheme()) > 8 }),
+			expect: []string{"Default Dark", "Solarized Light", "Tomorrow Night"},
+		},
+		"color near": {
+			query:  NewQuery().ColorNear("base00", NewColor("1d1f21"), 0.05),
+			expect: []string{"Tomorrow Night", "Default Dark"},
+		},
+	}
+	for name, tc := range testCases {
+		got := schemeNames(tc.query.Run(schemes))
+		if !reflect.DeepEqual(tc.expect, got) {
+			t.Errorf("%s: expected value=%v, got=%v", name, tc.expect, got)
+		}
+	}
+}
+
+func TestQueryVariantMetadata(t *testing.T) {
+	schemes := testQuerySchemes()
+	schemes[0].SetVariant(VariantLight)
+
+	got := NewQuery().Light().Schemes(schemes)
+	if len(got) != 3 || got[2].Scheme() != "Tomorrow Night" {
+		t.Errorf("expected Tomorrow Night to match variant metadata, got=%v", got)
+	}
+}
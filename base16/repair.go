@@ -0,0 +1,99 @@
+package base16
+
+import (
+	"math"
+)
+
+// RepairChange describes a foreground color changed by RepairContrast.
+type RepairChange struct {
+	Name string
+	Old  Color
+	New  Color
+
+	// Backgrounds contains the color names of all backgrounds the color was
+	// checked against.
+	Backgrounds []string
+
+	// OldContrast and NewContrast are the lowest WCAG 2.x contrast ratios of
+	// the color against its backgrounds before and after the change.
+	OldContrast float64
+	NewContrast float64
+}
+
+// RepairContrast returns a copy of scheme in which all foreground colors
+// failing an audit pair (see Audit) are adjusted to pass. Each failing
+// foreground is changed minimally in OKLCh lightness until it passes all of
+// its pairs, hue and chroma are preserved. Backgrounds are never changed. The
+// optional arguments replace the default pairs (see DefaultAuditPairs).
+// Returns the changes ordered by the first pair of each foreground. A
+// foreground which cannot pass all of its pairs is left unchanged and not
+// reported, use Audit on the result to find remaining failures.
+func RepairContrast(scheme Scheme, pairs ...AuditPair) (Scheme, []RepairChange) {
+	if len(pairs) == 0 {
+		pairs = DefaultAuditPairs()
+	}
+	repaired := Clone(scheme)
+
+	// group the pairs by foreground keeping the order of the pairs
+	var foregrounds []string
+	byForeground := make(map[string][]AuditPair)
+	for _, pair := range pairs {
+		name := canonicalColorName(pair.Foreground)
+		if _, ok := byForeground[name]; !ok {
+			foregrounds = append(foregrounds, name)
+		}
+		byForeground[name] = append(byForeground[name], pair)
+	}
+
+	var changes []RepairChange
+	for _, name := range foregrounds {
+		fg := repaired.GetColor(name)
+		if fg == NoColor {
+			continue
+		}
+		var fgPairs []AuditPair
+		var backgrounds []string
+		for _, pair := range byForeground[name] {
+			if repaired.GetColor(pair.Background) != NoColor {
+				fgPairs = append(fgPairs, pair)
+				backgrounds = append(backgrounds, canonicalColorName(pair.Background))
+			}
+		}
+		accept := func(c Color) bool {
+			for _, pair := range fgPairs {
+				bg := repaired.GetColor(pair.Background)
+				if ContrastRatio(c, bg) < pair.MinContrast || math.Abs(APCAContrast(c, bg)) < pair.MinAPCA {
+					return false
+				}
+			}
+			return true
+		}
+		if len(fgPairs) == 0 || accept(fg) {
+			continue
+		}
+		fitted, ok := fitLightness(fg.OKLCh(), accept)
+		if !ok {
+			continue
+		}
+		repaired.SetColor(name, fitted.Color())
+		changes = append(changes, RepairChange{
+			Name:        name,
+			Old:         fg,
+			New:         fitted.Color(),
+			Backgrounds: backgrounds,
+			OldContrast: minContrast(fg, repaired, backgrounds),
+			NewContrast: minContrast(fitted.Color(), repaired, backgrounds),
+		})
+	}
+	return repaired, changes
+}
+
+// minContrast returns the lowest WCAG 2.x contrast ratio of c against the
+// backgrounds of scheme.
+func minContrast(c Color, scheme Scheme, backgrounds []string) float64 {
+	lowest := math.Inf(1)
+	for _, background := range backgrounds {
+		lowest = math.Min(lowest, ContrastRatio(c, scheme.GetColor(background)))
+	}
+	return lowest
+}
@@ -0,0 +1,71 @@
+// +build !integration
+
+package base16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRepairContrast(t *testing.T) {
+	scheme := newTomorrowNight()
+	failing := make(map[string]bool)
+	for _, failure := range Audit(scheme).Failures() {
+		failing[failure.Foreground] = true
+	}
+	if len(failing) == 0 {
+		t.Fatalf("expected the test scheme to fail the audit")
+	}
+
+	repaired, changes := RepairContrast(scheme)
+	if report := Audit(repaired); !report.Pass() {
+		t.Errorf("expected the repaired scheme to pass, got failures %+v", report.Failures())
+	}
+	if len(changes) != len(failing) {
+		t.Errorf("expected value=%v, got=%v", len(failing), len(changes))
+	}
+	for _, change := range changes {
+		if !failing[change.Name] {
+			t.Errorf("unexpected change of passing color %s", change.Name)
+		}
+		if change.Old != scheme.GetColor(change.Name) || change.New != repaired.GetColor(change.Name) {
+			t.Errorf("%s: unexpected change %+v", change.Name, change)
+		}
+		if change.NewContrast <= change.OldContrast || len(change.Backgrounds) == 0 {
+			t.Errorf("%s: expected contrast to increase, got %+v", change.Name, change)
+		}
+		// small changes of dark colors may shift the hue of the 8 bit color
+		if d := math.Abs(hueDelta(change.Old.OKLCh().H, change.New.OKLCh().H)); d > 5 {
+			t.Errorf("%s: expected hue to be preserved, got delta=%f", change.Name, d)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if repaired.ColorAt(i) != scheme.ColorAt(i) {
+			t.Errorf("expected background %s to be unchanged", ColorIndexName(i))
+		}
+	}
+	if scheme.GetColor("base03") != NewColor("969896") {
+		t.Errorf("expected the original scheme to be unchanged")
+	}
+}
+
+func TestRepairContrastCustomPairs(t *testing.T) {
+	scheme := newTomorrowNight()
+	repaired, changes := RepairContrast(scheme, AuditPair{Role: AuditComment, Foreground: "BASE03", Background: "base00", MinContrast: 7})
+	if len(changes) != 1 || changes[0].Name != "base03" {
+		t.Fatalf("expected a single change of base03, got %+v", changes)
+	}
+	if ratio := ContrastRatio(repaired.GetColor("base03"), repaired.GetColor("base00")); ratio < 7 {
+		t.Errorf("expected contrast >= %f, got=%f", 7.0, ratio)
+	}
+	// the minimal change reaches the target closely
+	if changes[0].NewContrast > 7.5 {
+		t.Errorf("expected a minimal change, got contrast=%f", changes[0].NewContrast)
+	}
+
+	// unreachable targets leave the color unchanged
+	repaired, changes = RepairContrast(scheme, AuditPair{Foreground: "base03", Background: "base00", MinContrast: 21})
+	if len(changes) != 0 || repaired.GetColor("base03") != scheme.GetColor("base03") {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
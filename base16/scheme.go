@@ -12,6 +12,12 @@ const (
 	// Base16DefaultColors specifies the default number of colors of a base16
 	// scheme.
 	Base16DefaultColors = 16
+
+	// VariantDark is the variant name of a scheme with a dark background.
+	VariantDark = "dark"
+
+	// VariantLight is the variant name of a scheme with a light background.
+	VariantLight = "light"
 )
 
 // Scheme defines the interface for a base16 scheme.
@@ -28,25 +34,50 @@ type Scheme interface {
 	// SetScheme sets the scheme identifier of the scheme
 	SetScheme(name string)
 
+	// Variant returns the scheme variant (e.g. "dark" or "light")
+	Variant() string
+
+	// SetVariant sets the variant of the scheme
+	SetVariant(variant string)
+
 	// CountColors returns the number of colors
 	CountColors() int
 
-	// GetColor returns the color specified by colorname
+	// GetColor returns the color specified by colorname. Returns NoColor if
+	// the scheme does not define colorname.
 	GetColor(colorname string) Color
 
 	// GetColorNames returns a sorted string slice of all color names
 	GetColorNames() []string
 
-	// SetColor sets the color c for color name
-	SetColor(colorname string, c Color)
+	// SetColor sets the color c for color name. Returns an error if the
+	// scheme does not define colorname.
+	SetColor(colorname string, c Color) error
+
+	// ColorAt returns the color at index (zero based). Returns NoColor if the
+	// index is out of range.
+	ColorAt(index int) Color
+
+	// SetColorAt sets the color c at index (zero based). Returns an error if
+	// the index is out of range.
+	SetColorAt(index int, c Color) error
+
+	// Colors returns all colors with their names ordered by index
+	Colors() []NamedColor
 
 	// ExtendedModeOn returns the extended mode flag
 	ExtendedModeOn() bool
 }
 
-// SchemeData is the internal representation of a base16 colors scheme. All color
-// names are converted to lower case characters in order to avoid confusion when
-// accessing color names.
+// NamedColor is a color together with its base16 color name.
+type NamedColor struct {
+	Name  string
+	Color Color
+}
+
+// SchemeData is the internal representation of a base16 colors scheme. Color
+// names are case insensitive, they are converted to the canonical form (e.g.
+// "base0A") in order to avoid confusion when accessing color names.
 type SchemeData struct {
 	// auther contains the author of the scheme
 	author string
@@ -54,11 +85,18 @@ type SchemeData struct {
 	// scheme holds the scheme identifier (or name)
 	scheme string
 
-	// colors holds all base16 colors in a string map. Color names must be in
-	// upper case hex format.
-	colors map[string]Color
+	// variant holds the optional scheme variant (see VariantDark and
+	// VariantLight)
+	variant string
+
+	// colors holds all base16 colors indexed by their color index. Only the
+	// first countColors entries are used.
+	colors [ExtendedModeMaxColors]Color
 
-	// // sortedColorNames contains all color names sorted alphabetically.
+	// countColors holds the number of colors of the scheme.
+	countColors int
+
+	// sortedColorNames contains all color names sorted alphabetically.
 	sortedColorNames []string
 
 	// extendedMode is a flag which will be set when more than 16 colors are
@@ -91,11 +129,11 @@ func NewScheme(schemeName string, author string, countColorsOverride ...int) (Sc
 		author:           author,
 		extendedMode:     extendedMode,
 		sortedColorNames: ColorNames(countColors),
-		colors:           make(map[string]Color, countColors),
+		countColors:      countColors,
 	}
 
-	for _, k := range scheme.sortedColorNames {
-		scheme.colors[k] = NoColor
+	for i := range scheme.colors {
+		scheme.colors[i] = NoColor
 	}
 	return &scheme, nil
 }
@@ -120,27 +158,119 @@ func (scheme *SchemeData) SetScheme(name string) {
 	scheme.scheme = name
 }
 
+// Variant returns the scheme variant (e.g. "dark" or "light")
+func (scheme *SchemeData) Variant() string {
+	return scheme.variant
+}
+
+// SetVariant sets the variant of the scheme
+func (scheme *SchemeData) SetVariant(variant string) {
+	scheme.variant = variant
+}
+
 // CountColors returns the number of colors
 func (scheme *SchemeData) CountColors() int {
-	return len(scheme.colors)
+	return scheme.countColors
 }
 
-// GetColor returns the color specified by colorname
+// GetColor returns the color specified by colorname. Returns NoColor if the
+// scheme does not define colorname.
 func (scheme *SchemeData) GetColor(colorname string) Color {
-	return scheme.colors[colorname]
+	return scheme.ColorAt(ColorNameIndex(colorname))
 }
 
-// GetColorNames returns a sorted string slice of all color names
+// GetColorNames returns a sorted string slice of all color names. The returned
+// slice is a copy and may be modified by the caller.
 func (scheme *SchemeData) GetColorNames() []string {
-	return scheme.sortedColorNames
+	return append([]string(nil), scheme.sortedColorNames...)
+}
+
+// SetColor sets the color c for color name. Returns an error if colorname is
+// not a valid color name (see ColorNameIndex) or exceeds the number of colors
+// of the scheme.
+func (scheme *SchemeData) SetColor(colorname string, c Color) error {
+	index := ColorNameIndex(colorname)
+	if index < 0 || index >= scheme.countColors {
+		return fmt.Errorf("invalid color name %q for a scheme with %d colors", colorname, scheme.countColors)
+	}
+	scheme.colors[index] = c
+	return nil
+}
+
+// ColorAt returns the color at index (zero based). Returns NoColor if the index
+// is out of range.
+func (scheme *SchemeData) ColorAt(index int) Color {
+	if index < 0 || index >= scheme.countColors {
+		return NoColor
+	}
+	return scheme.colors[index]
+}
+
+// SetColorAt sets the color c at index (zero based). Returns an error if the
+// index is out of range.
+func (scheme *SchemeData) SetColorAt(index int, c Color) error {
+	if index < 0 || index >= scheme.countColors {
+		return fmt.Errorf("color index %d out of range for a scheme with %d colors", index, scheme.countColors)
+	}
+	scheme.colors[index] = c
+	return nil
 }
 
-// SetColor sets the color c for color name
-func (scheme *SchemeData) SetColor(colorname string, c Color) {
-	scheme.colors[colorname] = c
+// Colors returns all colors with their names ordered by index
+func (scheme *SchemeData) Colors() []NamedColor {
+	colors := make([]NamedColor, scheme.countColors)
+	for i := range colors {
+		colors[i] = NamedColor{Name: scheme.sortedColorNames[i], Color: scheme.colors[i]}
+	}
+	return colors
 }
 
 // ExtendedModeOn returns the extended mode flag
 func (scheme *SchemeData) ExtendedModeOn() bool {
 	return scheme.extendedMode
 }
+
+// Clone returns a deep copy of scheme. The copy is always a *SchemeData,
+// regardless of the implementation of scheme.
+func Clone(scheme Scheme) Scheme {
+	clone := &SchemeData{
+		scheme:           scheme.Scheme(),
+		author:           scheme.Author(),
+		variant:          scheme.Variant(),
+		extendedMode:     scheme.ExtendedModeOn(),
+		sortedColorNames: scheme.GetColorNames(),
+		countColors:      scheme.CountColors(),
+	}
+	for i := range clone.colors {
+		clone.colors[i] = scheme.ColorAt(i)
+	}
+	return clone
+}
+
+// Equal returns true if a and b have the same metadata and colors.
+func Equal(a Scheme, b Scheme) bool {
+	return a.CountColors() == b.CountColors() && Diff(a, b).Empty()
+}
+
+// DetectVariant returns the variant of scheme. The variant metadata is used
+// when set, otherwise the variant is derived from the luminance of the
+// background color (base00). Returns an empty string if neither is available.
+func DetectVariant(scheme Scheme) string {
+	if scheme.Variant() != "" {
+		return scheme.Variant()
+	}
+	return backgroundVariant(scheme.GetColor("base00"))
+}
+
+// backgroundVariant returns the variant matching the lightness of background.
+// Returns an empty string for NoColor.
+func backgroundVariant(background Color) string {
+	if background == NoColor {
+		return ""
+	}
+	// a relative luminance of 0.18 corresponds to a perceptual lightness of 50%
+	if background.Luminance() < 0.18 {
+		return VariantDark
+	}
+	return VariantLight
+}
@@ -211,3 +211,184 @@ func TestSetScheme(t *testing.T) {
 		t.Errorf("expected value=%s, got=%s", expectString, gotString)
 	}
 }
+
+func TestSetVariant(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+
+	if scheme.Variant() != "" {
+		t.Errorf("expected empty variant, got=%s", scheme.Variant())
+	}
+	scheme.SetVariant(VariantLight)
+	expectString := "light"
+	gotString := scheme.Variant()
+	if gotString != expectString {
+		t.Errorf("expected value=%s, got=%s", expectString, gotString)
+	}
+}
+
+func TestGetColorNamesCopy(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+
+	names := scheme.GetColorNames()
+	names[0] = "foo"
+	if scheme.GetColorNames()[0] != "base00" {
+		t.Errorf("expected value=%s, got=%s", "base00", scheme.GetColorNames()[0])
+	}
+}
+
+func TestClone(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody", 20)
+	scheme.SetVariant(VariantDark)
+	scheme.SetColor("base13", NewColor("ff0000"))
+
+	clone := Clone(scheme)
+	if !Equal(scheme, clone) {
+		t.Fatalf("expected clone to be equal, diff=%v", Diff(scheme, clone))
+	}
+	if !clone.ExtendedModeOn() {
+		t.Errorf("expected ExtendedModeOn()=true, got=false")
+	}
+
+	clone.SetColor("base13", NewColor("00ff00"))
+	clone.SetAuthor("fred")
+	if scheme.GetColor("base13") != NewColor("ff0000") || scheme.Author() != "nobody" {
+		t.Errorf("expected original scheme to be unchanged")
+	}
+	if Equal(scheme, clone) {
+		t.Errorf("expected modified clone not to be equal")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, _ := NewScheme("test", "nobody")
+	b, _ := NewScheme("test", "nobody", 17)
+	if Equal(a, b) {
+		t.Errorf("expected schemes with different color count not to be equal")
+	}
+	b, _ = NewScheme("test", "nobody")
+	if !Equal(a, b) {
+		t.Errorf("expected schemes to be equal")
+	}
+}
+
+func TestSetColorValidation(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+
+	for _, colorname := range []string{"base10", "base99", "foo", "base0G", "xbase0A"} {
+		if err := scheme.SetColor(colorname, NewColor("ff0000")); err == nil {
+			t.Errorf("expected error for colorname=%s", colorname)
+		}
+	}
+	if scheme.CountColors() != 16 {
+		t.Errorf("expected value=%d, got=%d", 16, scheme.CountColors())
+	}
+	if scheme.GetColor("foo") != NoColor {
+		t.Errorf("expected NoColor for an undefined color name")
+	}
+
+	extended, _ := NewScheme("test", "nobody", 18)
+	if err := extended.SetColor("base11", NewColor("ff0000")); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := extended.SetColor("base12", NewColor("ff0000")); err == nil {
+		t.Errorf("expected error for colorname=base12")
+	}
+}
+
+func TestSetColorCaseInsensitive(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+
+	if err := scheme.SetColor("base0a", NewColor("ff0000")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := scheme.SetColor("BASE0b", NewColor("00ff00")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.GetColor("base0A") != NewColor("ff0000") || scheme.GetColor("Base0A") != NewColor("ff0000") {
+		t.Errorf("expected value=%s, got=%s", "ff0000", scheme.GetColor("base0A").ToHexString())
+	}
+	if scheme.GetColor("base0B") != NewColor("00ff00") {
+		t.Errorf("expected value=%s, got=%s", "00ff00", scheme.GetColor("base0B").ToHexString())
+	}
+	if scheme.CountColors() != 16 {
+		t.Errorf("expected value=%d, got=%d", 16, scheme.CountColors())
+	}
+}
+
+func TestColorAt(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody", 18)
+
+	if err := scheme.SetColorAt(10, NewColor("f7ca88")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.ColorAt(10) != NewColor("f7ca88") || scheme.GetColor("base0A") != NewColor("f7ca88") {
+		t.Errorf("expected value=%s, got=%s", "f7ca88", scheme.ColorAt(10).ToHexString())
+	}
+	scheme.SetColor("base11", NewColor("ff0000"))
+	if scheme.ColorAt(17) != NewColor("ff0000") {
+		t.Errorf("expected value=%s, got=%s", "ff0000", scheme.ColorAt(17).ToHexString())
+	}
+
+	for _, index := range []int{-1, 18, 32} {
+		if err := scheme.SetColorAt(index, NewColor("000000")); err == nil {
+			t.Errorf("expected error for index=%d", index)
+		}
+		if scheme.ColorAt(index) != NoColor {
+			t.Errorf("expected NoColor for index=%d", index)
+		}
+	}
+}
+
+func TestColors(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+	for i := 0; i < scheme.CountColors(); i++ {
+		scheme.SetColorAt(i, NewColorRGB(uint8(i), 0, 0))
+	}
+
+	colors := scheme.Colors()
+	if len(colors) != 16 {
+		t.Fatalf("expected value=%d, got=%d", 16, len(colors))
+	}
+	for i, named := range colors {
+		if named.Name != ColorIndexName(i) || named.Color != NewColorRGB(uint8(i), 0, 0) {
+			t.Errorf("unexpected color %v at index %d", named, i)
+		}
+	}
+}
+
+func TestGetColorAllocations(t *testing.T) {
+	scheme, _ := NewScheme("test", "nobody")
+	allocs := testing.AllocsPerRun(100, func() {
+		scheme.GetColor("base0A")
+		scheme.GetColor("base0a")
+		scheme.ColorAt(10)
+		scheme.SetColor("base0A", NoColor)
+	})
+	if allocs != 0 {
+		t.Errorf("expected value=%d allocations, got=%f", 0, allocs)
+	}
+}
+
+func BenchmarkGetColor(b *testing.B) {
+	scheme, _ := NewScheme("test", "nobody")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scheme.GetColor("base0A")
+	}
+}
+
+func BenchmarkSetColor(b *testing.B) {
+	scheme, _ := NewScheme("test", "nobody")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scheme.SetColor("base0A", NoColor)
+	}
+}
+
+func BenchmarkColorAt(b *testing.B) {
+	scheme, _ := NewScheme("test", "nobody")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scheme.ColorAt(10)
+	}
+}
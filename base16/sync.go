@@ -0,0 +1,137 @@
+package base16
+
+import (
+	"sync"
+)
+
+// SyncScheme implements the Scheme interface by guarding another Scheme with a
+// read/write mutex. It is safe for concurrent use by multiple goroutines, e.g.
+// render goroutines calling GetColor while another goroutine calls SetColor.
+type SyncScheme struct {
+	mu     sync.RWMutex
+	scheme Scheme
+}
+
+// NewSyncScheme returns a concurrency safe scheme wrapping scheme. The caller
+// must not access scheme directly afterwards.
+func NewSyncScheme(scheme Scheme) *SyncScheme {
+	return &SyncScheme{scheme: scheme}
+}
+
+// Author returns the author of the scheme
+func (s *SyncScheme) Author() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.Author()
+}
+
+// SetAuthor sets the author name of the scheme
+func (s *SyncScheme) SetAuthor(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheme.SetAuthor(name)
+}
+
+// Scheme returns the scheme identifier (name)
+func (s *SyncScheme) Scheme() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.Scheme()
+}
+
+// SetScheme sets the scheme identifier of the scheme
+func (s *SyncScheme) SetScheme(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheme.SetScheme(name)
+}
+
+// Variant returns the scheme variant (e.g. "dark" or "light")
+func (s *SyncScheme) Variant() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.Variant()
+}
+
+// SetVariant sets the variant of the scheme
+func (s *SyncScheme) SetVariant(variant string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheme.SetVariant(variant)
+}
+
+// CountColors returns the number of colors
+func (s *SyncScheme) CountColors() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.CountColors()
+}
+
+// GetColor returns the color specified by colorname
+func (s *SyncScheme) GetColor(colorname string) Color {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.GetColor(colorname)
+}
+
+// GetColorNames returns a sorted string slice of all color names
+func (s *SyncScheme) GetColorNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.GetColorNames()
+}
+
+// SetColor sets the color c for color name while holding the write lock.
+// Returns the error of the wrapped scheme (e.g. for an invalid color name).
+func (s *SyncScheme) SetColor(colorname string, c Color) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scheme.SetColor(colorname, c)
+}
+
+// ColorAt returns the color at index (zero based)
+func (s *SyncScheme) ColorAt(index int) Color {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.ColorAt(index)
+}
+
+// SetColorAt sets the color c at index (zero based) while holding the write
+// lock. Returns the error of the wrapped scheme (e.g. for an index out of
+// range).
+func (s *SyncScheme) SetColorAt(index int, c Color) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scheme.SetColorAt(index, c)
+}
+
+// Colors returns all colors with their names ordered by index
+func (s *SyncScheme) Colors() []NamedColor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.Colors()
+}
+
+// ExtendedModeOn returns the extended mode flag
+func (s *SyncScheme) ExtendedModeOn() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scheme.ExtendedModeOn()
+}
+
+// Snapshot returns a consistent copy of the scheme (see Clone). The copy is
+// not synchronized and not affected by later changes.
+func (s *SyncScheme) Snapshot() Scheme {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Clone(s.scheme)
+}
+
+// Update calls f with the wrapped scheme while holding the write lock. Use
+// Update to apply several changes atomically. The scheme passed to f must not
+// be retained after f returns.
+func (s *SyncScheme) Update(f func(scheme Scheme)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.scheme)
+}
@@ -0,0 +1,78 @@
+// +build !integration
+
+package base16
+
+import (
+	"sync"
+	"testing"
+)
+
+// Run with the race detector (go test -race) to verify the synchronization.
+func TestSyncSchemeConcurrentAccess(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewSyncScheme(data)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				for _, colorname := range scheme.GetColorNames() {
+					scheme.GetColor(colorname)
+				}
+				scheme.Author()
+				scheme.Snapshot()
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				scheme.SetColor(ColorIndexName(j%16), NewColorRGB(uint8(i), uint8(j), 0))
+				scheme.SetAuthor("fred")
+				scheme.SetVariant(VariantDark)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if scheme.CountColors() != 16 {
+		t.Errorf("expected value=%d, got=%d", 16, scheme.CountColors())
+	}
+}
+
+func TestSyncSchemeUpdate(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewSyncScheme(data)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scheme.Update(func(s Scheme) {
+				s.SetColor("base00", s.GetColor("base00")+1)
+			})
+		}()
+	}
+	wg.Wait()
+
+	// base00 starts as NoColor (-1)
+	if got := scheme.GetColor("base00"); got != 7 {
+		t.Errorf("expected value=%d, got=%d", 7, got)
+	}
+}
+
+func TestSyncSchemeSnapshot(t *testing.T) {
+	data, _ := NewScheme("test", "nobody")
+	scheme := NewSyncScheme(data)
+	scheme.SetColor("base00", NewColor("181818"))
+
+	snapshot := scheme.Snapshot()
+	scheme.SetColor("base00", NewColor("ffffff"))
+	scheme.SetScheme("changed")
+
+	if snapshot.GetColor("base00") != NewColor("181818") || snapshot.Scheme() != "test" {
+		t.Errorf("expected snapshot to be unchanged")
+	}
+}
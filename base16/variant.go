@@ -0,0 +1,84 @@
+package base16
+
+import (
+	"fmt"
+	"math"
+)
+
+// darkBackgroundL and lightBackgroundL limit the OKLCh lightness of the
+// background (base00) of an inverted scheme: a dark background is at most as
+// light as darkBackgroundL, a light background at least as light as
+// lightBackgroundL. minDarkBackgroundL and maxLightBackgroundL keep the
+// background away from pure black and white.
+const (
+	darkBackgroundL     = 0.25
+	lightBackgroundL    = 0.95
+	minDarkBackgroundL  = 0.2
+	maxLightBackgroundL = 0.98
+)
+
+// Invert returns a copy of scheme with the opposite polarity, e.g. a light
+// variant of a dark scheme. The lightness of the ramp base00 - base07 is
+// inverted in OKLCh and stretched so the background reaches a typical light
+// (or dark) background lightness, chroma and hue are preserved. The ramp is
+// kept strictly monotonic and base05 is moved away from the background until
+// it reaches the contrast ratio against base00. The accents
+// base08 - base0F keep their hue and chroma, their lightness is adjusted
+// minimally to reach the contrast ratio against the new background. The
+// optional argument sets the minimum WCAG contrast ratio of base05 and the
+// accents (defaults to DefaultContrast). Additional colors of extended schemes
+// are copied unchanged. The variant metadata of the copy is set to the new
+// polarity. Returns an error if a color of base00 - base0F is not defined or
+// the contrast ratio of base05 cannot be reached.
+func Invert(scheme Scheme, contrast ...float64) (Scheme, error) {
+	target := DefaultContrast
+	if len(contrast) == 1 {
+		target = contrast[0]
+	}
+	if target < 1 || target > 21 {
+		return nil, fmt.Errorf("contrast must be in the range [1, 21], got %g", target)
+	}
+	var ramp [8]OKLCh
+	var accents [8]OKLCh
+	for i := 0; i < Base16DefaultColors; i++ {
+		c := scheme.ColorAt(i)
+		if c == NoColor {
+			return nil, fmt.Errorf("color %s is not defined", ColorIndexName(i))
+		}
+		if i < 8 {
+			ramp[i] = c.OKLCh()
+		} else {
+			accents[i-8] = c.OKLCh()
+		}
+	}
+
+	// the polarity is taken from the ramp, the variant metadata may be wrong
+	// or missing
+	toDark := ramp[0].L > ramp[7].L
+	first, last := 1-ramp[0].L, 1-ramp[7].L
+	background := math.Max(minDarkBackgroundL, math.Min(first, darkBackgroundL))
+	variant := VariantDark
+	if !toDark {
+		background = math.Min(maxLightBackgroundL, math.Max(first, lightBackgroundL))
+		variant = VariantLight
+	}
+
+	for i, lch := range ramp {
+		l := 1 - lch.L
+		if first != last {
+			l = background + (l-first)*(last-background)/(last-first)
+		}
+		ramp[i].L = l
+	}
+	if !fitRamp(&ramp, toDark, target) {
+		return nil, fmt.Errorf("contrast %g cannot be reached by the inverted ramp", target)
+	}
+
+	inverted := Clone(scheme)
+	inverted.SetVariant(variant)
+	for i, lch := range ramp {
+		inverted.SetColorAt(i, lch.Color())
+	}
+	setAccents(inverted, accents, target)
+	return inverted, nil
+}
@@ -0,0 +1,63 @@
+// +build !integration
+
+package base16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInvert(t *testing.T) {
+	scheme := newTomorrowNight()
+	light, err := Invert(scheme)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if light.Variant() != VariantLight || light.Scheme() != "Tomorrow Night" {
+		t.Errorf("unexpected metadata scheme=%s variant=%s", light.Scheme(), light.Variant())
+	}
+	if scheme.GetColor("base00") != NewColor("1d1f21") || scheme.Variant() != "" {
+		t.Errorf("expected the original scheme to be unchanged")
+	}
+	if l := light.ColorAt(0).OKLCh().L; l < lightBackgroundL-0.01 {
+		t.Errorf("expected background lightness >= %f, got=%f", lightBackgroundL, l)
+	}
+	for i := 1; i < 8; i++ {
+		if light.ColorAt(i).OKLCh().L >= light.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be less than %s", ColorIndexName(i), ColorIndexName(i-1))
+		}
+	}
+	for _, i := range []int{5, 8, 9, 10, 11, 12, 13, 14, 15} {
+		if ratio := ContrastRatio(light.ColorAt(i), light.ColorAt(0)); ratio < DefaultContrast {
+			t.Errorf("%s: expected contrast >= %f, got=%f", ColorIndexName(i), DefaultContrast, ratio)
+		}
+	}
+	for i := 8; i < 16; i++ {
+		if d := math.Abs(hueDelta(light.ColorAt(i).OKLCh().H, scheme.ColorAt(i).OKLCh().H)); d > 10 {
+			t.Errorf("%s: expected hue to be preserved, got delta=%f", ColorIndexName(i), d)
+		}
+	}
+
+	dark, err := Invert(light, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dark.Variant() != VariantDark || dark.ColorAt(0).OKLCh().L > darkBackgroundL+0.01 {
+		t.Errorf("expected dark variant, got=%s", dark.Variant())
+	}
+	for i := 1; i < 8; i++ {
+		if dark.ColorAt(i).OKLCh().L <= dark.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be greater than %s", ColorIndexName(i), ColorIndexName(i-1))
+		}
+	}
+}
+
+func TestInvertErrorHandling(t *testing.T) {
+	scheme, _ := NewScheme("empty", "")
+	if _, err := Invert(scheme); err == nil {
+		t.Errorf("expected error for undefined colors")
+	}
+	if _, err := Invert(newTomorrowNight(), 22); err == nil {
+		t.Errorf("expected error for invalid contrast")
+	}
+}
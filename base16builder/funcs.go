@@ -0,0 +1,317 @@
+package base16builder
+
+import (
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"math"
+	"strings"
+	"text/template"
+)
+
+// ColorRoles holds the role names of base00 - base0F following the base16
+// styling guidelines.
+var ColorRoles = [base16.Base16DefaultColors]string{
+	"background",      // base00 default background
+	"altBackground",   // base01 lighter background (status bars, line numbers)
+	"selection",       // base02 selection background
+	"comment",         // base03 comments, invisibles, line highlighting
+	"darkForeground",  // base04 dark foreground (status bars)
+	"foreground",      // base05 default foreground, caret, delimiters
+	"lightForeground", // base06 light foreground
+	"lightBackground", // base07 light background
+	"red",             // base08 variables, tags, diff deleted
+	"orange",          // base09 integers, booleans, constants
+	"yellow",          // base0A classes, search text background
+	"green",           // base0B strings, diff inserted
+	"cyan",            // base0C support, regular expressions, escapes
+	"blue",            // base0D functions, methods, headings
+	"magenta",         // base0E keywords, storage, diff changed
+	"brown",           // base0F deprecated, embedded language tags
+}
+
+// TemplateColor is a color of TemplateData. The methods return the different
+// forms of the color, printing a TemplateColor prints its hex form.
+type TemplateColor struct {
+	// Name is the color name (e.g. "base0D").
+	Name string
+
+	// Role is the role name (e.g. "blue", see ColorRoles). Empty for the
+	// additional colors of extended schemes and derived colors.
+	Role string
+
+	Color base16.Color
+}
+
+// String returns the hex form of the color (e.g. "7cafc2").
+func (c TemplateColor) String() string {
+	return c.Hex()
+}
+
+// Hex returns the hex form of the color (e.g. "7cafc2").
+func (c TemplateColor) Hex() string {
+	return c.Color.ToHexString()
+}
+
+// HexBGR returns the hex form of the color in blue, green, red order (e.g.
+// "c2af7c").
+func (c TemplateColor) HexBGR() string {
+	r, g, b := c.Color.RGB()
+	return fmt.Sprintf("%02x%02x%02x", b, g, r)
+}
+
+// R returns the red component in the range 0 - 255.
+func (c TemplateColor) R() int {
+	r, _, _ := c.Color.RGB()
+	return int(r)
+}
+
+// G returns the green component in the range 0 - 255.
+func (c TemplateColor) G() int {
+	_, g, _ := c.Color.RGB()
+	return int(g)
+}
+
+// B returns the blue component in the range 0 - 255.
+func (c TemplateColor) B() int {
+	_, _, b := c.Color.RGB()
+	return int(b)
+}
+
+// RGB returns the components as comma separated list (e.g. "124, 175, 194").
+func (c TemplateColor) RGB() string {
+	r, g, b := c.Color.RGB()
+	return fmt.Sprintf("%d, %d, %d", r, g, b)
+}
+
+// DecR returns the red component in the range 0 - 1.
+func (c TemplateColor) DecR() float64 {
+	return float64(c.R()) / 255
+}
+
+// DecG returns the green component in the range 0 - 1.
+func (c TemplateColor) DecG() float64 {
+	return float64(c.G()) / 255
+}
+
+// DecB returns the blue component in the range 0 - 1.
+func (c TemplateColor) DecB() float64 {
+	return float64(c.B()) / 255
+}
+
+// Decimal returns the color as integer 0xRRGGBB (e.g. 8171458 for "7cafc2").
+func (c TemplateColor) Decimal() int {
+	return c.R()<<16 | c.G()<<8 | c.B()
+}
+
+// HSL returns the CSS hsl form of the color (e.g. "hsl(196, 36%, 62%)").
+func (c TemplateColor) HSL() string {
+	h, s, l := hsl(c.Color)
+	return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", h, s*100, l*100)
+}
+
+// hsl converts c to hue in degrees, saturation and lightness in the range
+// [0, 1].
+func hsl(c base16.Color) (h, s, l float64) {
+	r8, g8, b8 := c.RGB()
+	r, g, b := float64(r8)/255, float64(g8)/255, float64(b8)/255
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	d := max - min
+	if d == 0 {
+		return 0, 0, l
+	}
+	s = d / (1 - math.Abs(2*l-1))
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// TemplateData is the data model of a scheme for text/template templates.
+type TemplateData struct {
+	Scheme  string
+	Author  string
+	Slug    string
+	Variant string
+	Dark    bool
+	Light   bool
+
+	// Palette contains all defined colors ordered by index.
+	Palette []TemplateColor
+
+	// Colors contains all defined colors by color name (e.g.
+	// {{ .Colors.base0D }}). Templates created by NewGoTemplate fail to
+	// execute if an undefined color is referenced.
+	Colors map[string]TemplateColor
+
+	// Roles contains the defined colors of base00 - base0F by role name (e.g.
+	// {{ .Roles.foreground }}, see ColorRoles). Like Colors, undefined roles
+	// fail templates created by NewGoTemplate.
+	Roles map[string]TemplateColor
+}
+
+// NewTemplateData returns the template data of scheme. The optional argument
+// overrides the slug (see Variables).
+func NewTemplateData(scheme base16.Scheme, slug ...string) *TemplateData {
+	s := Slug(scheme.Scheme())
+	if len(slug) == 1 {
+		s = slug[0]
+	}
+	variant := base16.DetectVariant(scheme)
+	data := &TemplateData{
+		Scheme:  scheme.Scheme(),
+		Author:  scheme.Author(),
+		Slug:    s,
+		Variant: variant,
+		Dark:    variant == base16.VariantDark,
+		Light:   variant == base16.VariantLight,
+		Colors:  make(map[string]TemplateColor),
+		Roles:   make(map[string]TemplateColor),
+	}
+	for i, named := range scheme.Colors() {
+		if named.Color == base16.NoColor {
+			continue
+		}
+		c := TemplateColor{Name: named.Name, Color: named.Color}
+		if i < len(ColorRoles) {
+			c.Role = ColorRoles[i]
+			data.Roles[c.Role] = c
+		}
+		data.Palette = append(data.Palette, c)
+		data.Colors[c.Name] = c
+	}
+	return data
+}
+
+// FuncMap returns the color functions for text/template templates. Color
+// arguments can be a TemplateColor, a base16.Color or a hex string (e.g.
+// "7cafc2" or "#7cafc2"), undefined colors (NoColor) are an error. Functions
+// deriving colors return a TemplateColor:
+//
+//	hex COLOR                hex form (e.g. "7cafc2")
+//	rgb COLOR                comma separated components (e.g. "124, 175, 194")
+//	hsl COLOR                CSS hsl form (e.g. "hsl(196, 36%, 62%)")
+//	decimal COLOR            integer 0xRRGGBB
+//	lighten COLOR AMOUNT     increases the OKLCh lightness by AMOUNT (0 - 1)
+//	darken COLOR AMOUNT      decreases the OKLCh lightness by AMOUNT (0 - 1)
+//	mix COLOR COLOR T        mixes the colors in OKLab (T = 0 is the first)
+//	alpha COLOR ALPHA        hex form with alpha channel (e.g. "7cafc280")
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"hex":     func(v interface{}) (string, error) { return withColor(v, TemplateColor.Hex) },
+		"rgb":     func(v interface{}) (string, error) { return withColor(v, TemplateColor.RGB) },
+		"hsl":     func(v interface{}) (string, error) { return withColor(v, TemplateColor.HSL) },
+		"decimal": decimal,
+		"lighten": func(v interface{}, amount interface{}) (TemplateColor, error) { return adjustLightness(v, amount, 1) },
+		"darken":  func(v interface{}, amount interface{}) (TemplateColor, error) { return adjustLightness(v, amount, -1) },
+		"mix":     mix,
+		"alpha":   alpha,
+	}
+}
+
+// NewGoTemplate returns a new text/template template with the functions of
+// FuncMap parsed from text. Map keys missing from the data (e.g. an undefined
+// color in TemplateData.Colors) are an error on execution.
+func NewGoTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Funcs(FuncMap()).Parse(text)
+}
+
+func withColor(v interface{}, f func(TemplateColor) string) (string, error) {
+	c, err := toTemplateColor(v)
+	if err != nil {
+		return "", err
+	}
+	return f(c), nil
+}
+
+func decimal(v interface{}) (int, error) {
+	c, err := toTemplateColor(v)
+	if err != nil {
+		return 0, err
+	}
+	return c.Decimal(), nil
+}
+
+func adjustLightness(v interface{}, amount interface{}, sign float64) (TemplateColor, error) {
+	c, err := toTemplateColor(v)
+	if err != nil {
+		return TemplateColor{}, err
+	}
+	a, err := toFloat(amount)
+	if err != nil {
+		return TemplateColor{}, err
+	}
+	lch := c.Color.OKLCh()
+	lch.L = math.Max(0, math.Min(1, lch.L+sign*a))
+	return TemplateColor{Color: lch.Color()}, nil
+}
+
+func mix(a interface{}, b interface{}, t interface{}) (TemplateColor, error) {
+	ca, err := toTemplateColor(a)
+	if err != nil {
+		return TemplateColor{}, err
+	}
+	cb, err := toTemplateColor(b)
+	if err != nil {
+		return TemplateColor{}, err
+	}
+	f, err := toFloat(t)
+	if err != nil {
+		return TemplateColor{}, err
+	}
+	return TemplateColor{Color: ca.Color.Mix(cb.Color, f, base16.ColorSpaceOKLab)}, nil
+}
+
+func alpha(v interface{}, value interface{}) (string, error) {
+	c, err := toTemplateColor(v)
+	if err != nil {
+		return "", err
+	}
+	a, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+	a = math.Max(0, math.Min(1, a))
+	return fmt.Sprintf("%s%02x", c.Hex(), int(math.Round(a*255))), nil
+}
+
+func toTemplateColor(v interface{}) (TemplateColor, error) {
+	switch c := v.(type) {
+	case TemplateColor:
+		return definedColor(c)
+	case *TemplateColor:
+		return definedColor(*c)
+	case base16.Color:
+		return definedColor(TemplateColor{Color: c})
+	case string:
+		if color := base16.NewColor(strings.TrimPrefix(c, "#")); color != base16.NoColor {
+			return TemplateColor{Color: color}, nil
+		}
+		return TemplateColor{}, fmt.Errorf("invalid color %q", c)
+	}
+	return TemplateColor{}, fmt.Errorf("invalid color %v", v)
+}
+
+func definedColor(c TemplateColor) (TemplateColor, error) {
+	if c.Color == base16.NoColor && c.Name != "" {
+		return TemplateColor{}, fmt.Errorf("color %s is not defined", c.Name)
+	} else if c.Color == base16.NoColor {
+		return TemplateColor{}, fmt.Errorf("color is not defined")
+	}
+	return c, nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch f := v.(type) {
+	case float64:
+		return f, nil
+	case int:
+		return float64(f), nil
+	}
+	return 0, fmt.Errorf("invalid number %v", v)
+}
@@ -0,0 +1,123 @@
+package base16builder
+
+import (
+	"github.com/shebang-go/colorlib/base16"
+	"strings"
+	"testing"
+)
+
+func TestTemplateColor(t *testing.T) {
+	c := TemplateColor{Name: "base0D", Role: "blue", Color: base16.NewColor("7cafc2")}
+	values := map[string]interface{}{
+		"String":  c.String(),
+		"Hex":     c.Hex(),
+		"HexBGR":  c.HexBGR(),
+		"RGB":     c.RGB(),
+		"HSL":     c.HSL(),
+		"Decimal": c.Decimal(),
+		"R":       c.R(),
+		"DecB":    c.DecB(),
+	}
+	expected := map[string]interface{}{
+		"String":  "7cafc2",
+		"Hex":     "7cafc2",
+		"HexBGR":  "c2af7c",
+		"RGB":     "124, 175, 194",
+		"HSL":     "hsl(196, 36%, 62%)",
+		"Decimal": 0x7cafc2,
+		"R":       124,
+		"DecB":    194.0 / 255,
+	}
+	for name, value := range expected {
+		if values[name] != value {
+			t.Errorf("%s: expected value=%v, got=%v", name, value, values[name])
+		}
+	}
+	gray := TemplateColor{Color: base16.NewColor("808080")}
+	if gray.HSL() != "hsl(0, 0%, 50%)" {
+		t.Errorf("expected value=%v, got=%v", "hsl(0, 0%, 50%)", gray.HSL())
+	}
+}
+
+func TestNewTemplateData(t *testing.T) {
+	data := NewTemplateData(testScheme())
+	if data.Scheme != "Default Dark" || data.Slug != "default-dark" || data.Variant != base16.VariantDark || !data.Dark || data.Light {
+		t.Errorf("unexpected metadata %+v", data)
+	}
+	if len(data.Palette) != 16 || len(data.Colors) != 16 || len(data.Roles) != 16 {
+		t.Errorf("expected 16 colors, got palette=%d colors=%d roles=%d", len(data.Palette), len(data.Colors), len(data.Roles))
+	}
+	if c := data.Roles["foreground"]; c.Name != "base05" || c.Hex() != "d8d8d8" {
+		t.Errorf("unexpected foreground %+v", c)
+	}
+	if c := data.Colors["base0D"]; c.Role != "blue" {
+		t.Errorf("expected value=%v, got=%v", "blue", c.Role)
+	}
+}
+
+func TestGoTemplate(t *testing.T) {
+	text := strings.Join([]string{
+		`{{ .Scheme }} ({{ .Slug }}, {{ .Variant }})`,
+		`bg={{ .Roles.background }} fg=#{{ .Colors.base05.Hex }} rgb={{ rgb .Colors.base0D }}`,
+		`hsl={{ hsl "#7cafc2" }} decimal={{ decimal .Colors.base00 }}`,
+		`lighter={{ lighten .Roles.background 0.1 }} darker={{ darken .Roles.foreground 0.1 | hex }}`,
+		`mix={{ mix "000000" "ffffff" 0 }} alpha={{ alpha .Roles.selection 0.5 }}`,
+		`{{ range .Palette }}{{ .Name }}{{ if .Role }}:{{ .Role }}{{ end }} {{ end }}`,
+	}, "\n")
+	tmpl, err := NewGoTemplate("test", text)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, NewTemplateData(testScheme())); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	expected := []string{
+		"Default Dark (default-dark, dark)",
+		"bg=181818 fg=#d8d8d8 rgb=124, 175, 194",
+		"hsl=hsl(196, 36%, 62%) decimal=1579032",
+		"",
+		"mix=000000 alpha=38383880",
+		"base00:background base01:altBackground base02:selection",
+	}
+	for i, line := range expected {
+		if i == 3 {
+			continue
+		}
+		if !strings.HasPrefix(lines[i], line) {
+			t.Errorf("line %d: expected value=%q, got=%q", i, line, lines[i])
+		}
+	}
+
+	// lightness changes move towards white and black
+	lighter := base16.NewColor(strings.Fields(lines[3])[0][len("lighter="):])
+	darker := base16.NewColor(strings.Fields(lines[3])[1][len("darker="):])
+	if lighter.OKLCh().L <= base16.NewColor("181818").OKLCh().L || darker.OKLCh().L >= base16.NewColor("d8d8d8").OKLCh().L {
+		t.Errorf("unexpected lightness changes %q", lines[3])
+	}
+}
+
+func TestGoTemplateErrorHandling(t *testing.T) {
+	for _, text := range []string{`{{ hex "zzz" }}`, `{{ lighten "181818" "much" }}`, `{{ mix 1 "000000" 0.5 }}`, `{{ hex .NoColor }}`} {
+		tmpl, err := NewGoTemplate("test", text)
+		if err != nil {
+			t.Fatalf("%s: expected no parse error, got %v", text, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, map[string]interface{}{"NoColor": base16.NoColor}); err == nil {
+			t.Errorf("%s: expected error", text)
+		}
+	}
+
+	// undefined colors and roles are an error
+	scheme := testScheme()
+	scheme.SetColor("base0F", base16.NoColor)
+	for _, text := range []string{`{{ .Colors.base0F.Hex }}`, `{{ .Roles.brown }}`, `{{ .Colors.base10 }}`} {
+		tmpl, _ := NewGoTemplate("test", text)
+		var b strings.Builder
+		if err := tmpl.Execute(&b, NewTemplateData(scheme)); err == nil {
+			t.Errorf("%s: expected error, got output %q", text, b.String())
+		}
+	}
+}
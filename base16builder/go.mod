@@ -0,0 +1,10 @@
+module github.com/shebang-go/colorlib/base16builder
+
+go 1.16
+
+require (
+	github.com/shebang-go/colorlib/base16 v0.4.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/shebang-go/colorlib/base16 => ../base16
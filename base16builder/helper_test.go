@@ -0,0 +1,41 @@
+package base16builder
+
+import (
+	"github.com/shebang-go/colorlib/base16"
+	"strings"
+)
+
+// testScheme returns the Default Dark scheme.
+func testScheme() base16.Scheme {
+	scheme, _ := base16.NewScheme("Default Dark", "Chris Kempson (http://chriskempson.com)")
+	colors := []string{
+		"181818", "282828", "383838", "585858", "b8b8b8", "d8d8d8", "e8e8e8", "f8f8f8",
+		"ab4642", "dc9656", "f7ca88", "a1b56c", "86c1b9", "7cafc2", "ba8baf", "a16946",
+	}
+	for i, c := range colors {
+		scheme.SetColorAt(i, base16.NewColor(c))
+	}
+	return scheme
+}
+
+// itermTestColors returns a minimal preset with the given entries (key and
+// color dict) and all required colors set to black.
+func itermTestColors(entries map[string]string) string {
+	black := `<dict><key>Red Component</key><real>0</real><key>Green Component</key><real>0</real><key>Blue Component</key><real>0</real></dict>`
+	all := map[string]string{itermBackground: black, itermForeground: black}
+	for i := 0; i < 16; i++ {
+		all[itermANSIKey(i)] = black
+	}
+	for key, value := range entries {
+		all[key] = value
+	}
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?><plist version="1.0"><dict>`)
+	for key, value := range all {
+		if value != "" {
+			b.WriteString("<key>" + key + "</key>" + value)
+		}
+	}
+	b.WriteString("</dict></plist>")
+	return b.String()
+}
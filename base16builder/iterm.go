@@ -0,0 +1,235 @@
+package base16builder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// iTerm2 color keys
+const (
+	itermBackground    = "Background Color"
+	itermForeground    = "Foreground Color"
+	itermBold          = "Bold Color"
+	itermCursor        = "Cursor Color"
+	itermCursorText    = "Cursor Text Color"
+	itermSelection     = "Selection Color"
+	itermSelectedText  = "Selected Text Color"
+	itermColorSpace    = "Color Space"
+	itermColorSpaceP3  = "P3"
+	itermColorSpaceRGB = "sRGB"
+)
+
+func itermANSIKey(i int) string {
+	return fmt.Sprintf("Ansi %d Color", i)
+}
+
+// WriteITermColors writes the colors of scheme as iTerm2 color preset
+// (.itermcolors property list) to w. The colors are mapped like
+// NewTerminalPalette, bold text uses the foreground and selected text base05.
+// All colors are written in the sRGB color space. Returns an error if a color
+// of base00 - base0F is not defined.
+func WriteITermColors(w io.Writer, scheme base16.Scheme) error {
+	for i := 0; i < base16.Base16DefaultColors; i++ {
+		if scheme.ColorAt(i) == base16.NoColor {
+			return fmt.Errorf("color %s is not defined", base16.ColorIndexName(i))
+		}
+	}
+	p := NewTerminalPalette(scheme)
+	colors := map[string]base16.Color{
+		itermBackground:   p.Background.Color,
+		itermForeground:   p.Foreground.Color,
+		itermBold:         p.Foreground.Color,
+		itermCursor:       p.Cursor.Color,
+		itermCursorText:   p.CursorText.Color,
+		itermSelection:    p.SelectionBackground.Color,
+		itermSelectedText: p.SelectionForeground.Color,
+	}
+	for i, c := range p.ANSI {
+		colors[itermANSIKey(i)] = c.Color
+	}
+	keys := make([]string, 0, len(colors))
+	for key := range colors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	for _, key := range keys {
+		r, g, bl := colors[key].RGB()
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<dict>\n", key)
+		fmt.Fprintf(&b, "\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n")
+		fmt.Fprintf(&b, "\t\t<key>Blue Component</key>\n\t\t<real>%s</real>\n", itermComponent(bl))
+		fmt.Fprintf(&b, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", itermColorSpace, itermColorSpaceRGB)
+		fmt.Fprintf(&b, "\t\t<key>Green Component</key>\n\t\t<real>%s</real>\n", itermComponent(g))
+		fmt.Fprintf(&b, "\t\t<key>Red Component</key>\n\t\t<real>%s</real>\n", itermComponent(r))
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func itermComponent(v uint8) string {
+	return strconv.FormatFloat(float64(v)/255, 'f', -1, 64)
+}
+
+// plistNode is an element of a property list.
+type plistNode struct {
+	XMLName  xml.Name
+	Content  string      `xml:",chardata"`
+	Children []plistNode `xml:",any"`
+}
+
+// dict returns the entries of a <dict> node.
+func (n *plistNode) dict() (map[string]*plistNode, error) {
+	if n.XMLName.Local != "dict" || len(n.Children)%2 != 0 {
+		return nil, fmt.Errorf("invalid plist dict")
+	}
+	entries := make(map[string]*plistNode, len(n.Children)/2)
+	for i := 0; i < len(n.Children); i += 2 {
+		if n.Children[i].XMLName.Local != "key" {
+			return nil, fmt.Errorf("invalid plist dict: expected key, got %s", n.Children[i].XMLName.Local)
+		}
+		entries[strings.TrimSpace(n.Children[i].Content)] = &n.Children[i+1]
+	}
+	return entries, nil
+}
+
+// ReadITermColors reads an iTerm2 color preset (.itermcolors property list)
+// and returns a scheme with the given name. The mapping is the inverse of
+// WriteITermColors: base00 is the background, base05 the foreground, base02
+// the selection, base03 and base07 are bright black and bright white and the
+// accents are the ANSI colors. Colors without an ANSI equivalent are
+// approximated: base01, base04 and base06 are mixed from their ramp
+// neighbours, orange (base09) is mixed from red and yellow and brown (base0F)
+// is a darker, desaturated orange. A missing selection defaults to a mix of
+// background and foreground. Components in the P3 color space are converted
+// to sRGB, all other color spaces are treated as sRGB. Cursor and bold colors
+// are ignored. The variant is detected from the background.
+func ReadITermColors(r io.Reader, name string) (base16.Scheme, error) {
+	var plist struct {
+		Dict plistNode `xml:"dict"`
+	}
+	if err := xml.NewDecoder(r).Decode(&plist); err != nil {
+		return nil, err
+	}
+	entries, err := plist.Dict.dict()
+	if err != nil {
+		return nil, err
+	}
+	color := func(key string) (base16.Color, error) {
+		node, ok := entries[key]
+		if !ok {
+			return base16.NoColor, fmt.Errorf("missing color %q", key)
+		}
+		c, err := itermColor(node)
+		if err != nil {
+			return base16.NoColor, fmt.Errorf("%s: %v", key, err)
+		}
+		return c, nil
+	}
+
+	var colors [base16.Base16DefaultColors]base16.Color
+	// colors read from the preset by index
+	keys := map[int]string{
+		0x00: itermBackground,
+		0x03: itermANSIKey(8),
+		0x05: itermForeground,
+		0x07: itermANSIKey(15),
+	}
+	for i := 1; i < 7; i++ {
+		keys[base16.ColorNameIndex(ANSIColorNames[i])] = itermANSIKey(i)
+	}
+	for index := range colors {
+		if key, ok := keys[index]; ok {
+			if colors[index], err = color(key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	colors[0x02] = colors[0x00].Mix(colors[0x05], 0.2, base16.ColorSpaceOKLab)
+	if _, ok := entries[itermSelection]; ok {
+		if colors[0x02], err = color(itermSelection); err != nil {
+			return nil, err
+		}
+	}
+	colors[0x01] = colors[0x00].Mix(colors[0x02], 0.5, base16.ColorSpaceOKLab)
+	colors[0x04] = colors[0x03].Mix(colors[0x05], 0.5, base16.ColorSpaceOKLab)
+	colors[0x06] = colors[0x05].Mix(colors[0x07], 0.5, base16.ColorSpaceOKLab)
+	colors[0x09] = colors[0x08].Mix(colors[0x0A], 0.5, base16.ColorSpaceOKLCh)
+	brown := colors[0x09].OKLCh()
+	brown.L, brown.C = brown.L*0.8, brown.C*0.7
+	colors[0x0F] = brown.Color()
+
+	scheme, err := base16.NewScheme(name, "")
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range colors {
+		if err := scheme.SetColorAt(i, c); err != nil {
+			return nil, err
+		}
+	}
+	scheme.SetVariant(base16.DetectVariant(scheme))
+	return scheme, nil
+}
+
+// itermColor converts a color dict of an iTerm2 color preset.
+func itermColor(node *plistNode) (base16.Color, error) {
+	entries, err := node.dict()
+	if err != nil {
+		return base16.NoColor, err
+	}
+	var components [3]float64
+	for i, key := range []string{"Red Component", "Green Component", "Blue Component"} {
+		value, ok := entries[key]
+		if !ok {
+			return base16.NoColor, fmt.Errorf("missing %q", key)
+		}
+		components[i], err = strconv.ParseFloat(strings.TrimSpace(value.Content), 64)
+		if err != nil {
+			return base16.NoColor, fmt.Errorf("invalid %q: %v", key, err)
+		}
+	}
+	if space, ok := entries[itermColorSpace]; ok && strings.TrimSpace(space.Content) == itermColorSpaceP3 {
+		components = displayP3ToSRGB(components)
+	}
+	var rgb [3]uint8
+	for i, v := range components {
+		rgb[i] = uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return base16.NewColorRGB(rgb[0], rgb[1], rgb[2]), nil
+}
+
+// displayP3ToSRGB converts Display P3 components to sRGB components. Both
+// color spaces use the sRGB transfer function, out of gamut values are not
+// clipped.
+func displayP3ToSRGB(p3 [3]float64) [3]float64 {
+	toLinear := func(v float64) float64 {
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	fromLinear := func(v float64) float64 {
+		if v <= 0.0031308 {
+			return v * 12.92
+		}
+		return 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	r, g, b := toLinear(p3[0]), toLinear(p3[1]), toLinear(p3[2])
+	return [3]float64{
+		fromLinear(1.2249401*r - 0.2249404*g),
+		fromLinear(-0.0420569*r + 1.0420571*g),
+		fromLinear(-0.0196376*r - 0.0786361*g + 1.0982735*b),
+	}
+}
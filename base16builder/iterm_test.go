@@ -0,0 +1,117 @@
+package base16builder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/shebang-go/colorlib/base16"
+	"strings"
+	"testing"
+)
+
+func TestITermColorsRoundTrip(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteITermColors(&b, testScheme()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	scheme, err := ReadITermColors(&b, "Imported")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Scheme() != "Imported" || scheme.Variant() != base16.VariantDark {
+		t.Errorf("unexpected metadata scheme=%s variant=%s", scheme.Scheme(), scheme.Variant())
+	}
+	original := testScheme()
+	for _, name := range []string{"base00", "base02", "base03", "base05", "base07", "base08", "base0A", "base0B", "base0C", "base0D", "base0E"} {
+		if scheme.GetColor(name) != original.GetColor(name) {
+			t.Errorf("%s: expected value=%s, got=%s", name, original.GetColor(name).ToHexString(), scheme.GetColor(name).ToHexString())
+		}
+	}
+	// approximated colors lie between their neighbours
+	for _, name := range []string{"base01", "base04", "base06", "base09", "base0F"} {
+		if scheme.GetColor(name) == base16.NoColor {
+			t.Errorf("expected %s to be defined", name)
+		}
+	}
+	for i := 1; i < 8; i++ {
+		if scheme.ColorAt(i).OKLCh().L <= scheme.ColorAt(i-1).OKLCh().L {
+			t.Errorf("expected lightness of %s to be greater than %s", base16.ColorIndexName(i), base16.ColorIndexName(i-1))
+		}
+	}
+}
+
+func TestWriteITermColors(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteITermColors(&b, testScheme()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var plist struct {
+		Dict plistNode `xml:"dict"`
+	}
+	if err := xml.Unmarshal(b.Bytes(), &plist); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	entries, err := plist.Dict.dict()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// the mapping of the upstream base16-iterm2 template
+	expected := map[string]string{
+		itermCursor:      "base05",
+		itermCursorText:  "base00",
+		itermANSIKey(0):  "base00",
+		itermANSIKey(7):  "base05",
+		itermANSIKey(8):  "base03",
+		itermANSIKey(15): "base07",
+	}
+	scheme := testScheme()
+	for key, name := range expected {
+		node, ok := entries[key]
+		if !ok {
+			t.Errorf("expected %q to be written", key)
+			continue
+		}
+		if c, err := itermColor(node); err != nil || c != scheme.GetColor(name) {
+			t.Errorf("%s: expected value=%s, got=%s", key, scheme.GetColor(name).ToHexString(), c.ToHexString())
+		}
+	}
+}
+
+func TestReadITermColors(t *testing.T) {
+	// P3 white is sRGB white, P3 red is out of the sRGB gamut and clipped
+	p3 := map[string]string{
+		itermForeground: `<dict><key>Color Space</key><string>P3</string><key>Red Component</key><real>1</real><key>Green Component</key><real>1</real><key>Blue Component</key><real>1</real></dict>`,
+		itermANSIKey(1): `<dict><key>Color Space</key><string>P3</string><key>Red Component</key><real>1</real><key>Green Component</key><integer>0</integer><key>Blue Component</key><real>0</real></dict>`,
+		itermANSIKey(4): `<dict><key>Color Space</key><string>sRGB</string><key>Red Component</key><real>0.5</real><key>Green Component</key><real>0.25</real><key>Blue Component</key><real>1</real></dict>`,
+	}
+	scheme, err := ReadITermColors(strings.NewReader(itermTestColors(p3)), "p3")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]string{"base05": "ffffff", "base08": "ff0000", "base0D": "8040ff"}
+	for name, hex := range expected {
+		if got := scheme.GetColor(name).ToHexString(); got != hex {
+			t.Errorf("%s: expected value=%v, got=%v", name, hex, got)
+		}
+	}
+	// the selection defaults to a mix of background and foreground
+	if c := scheme.GetColor("base02"); c == base16.NoColor || c == scheme.GetColor("base00") {
+		t.Errorf("unexpected selection %s", c.ToHexString())
+	}
+}
+
+func TestReadITermColorsErrorHandling(t *testing.T) {
+	testCases := []string{
+		"not xml",
+		itermTestColors(map[string]string{itermBackground: ""}),
+		itermTestColors(map[string]string{itermForeground: `<dict><key>Red Component</key><real>x</real></dict>`}),
+		itermTestColors(map[string]string{itermForeground: `<dict><key>Red Component</key><real>1</real></dict>`}),
+		itermTestColors(map[string]string{itermSelection: `<dict><key>Red Component</key><real>1</real></dict>`}),
+		itermTestColors(map[string]string{itermSelection: `<string>blue</string>`}),
+		`<plist version="1.0"><dict><key>Background Color</key></dict></plist>`,
+	}
+	for _, tc := range testCases {
+		if _, err := ReadITermColors(strings.NewReader(tc), "invalid"); err == nil {
+			t.Errorf("expected error for %.60q", tc)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package base16builder
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Template is a parsed mustache template. It supports the subset of mustache
+// used by base16 templates: variables ({{name}} is HTML escaped, {{{name}}}
+// and {{& name}} are not), sections ({{#name}}...{{/name}}), inverted sections
+// ({{^name}}...{{/name}}) and comments ({{! comment}}). Partials render
+// nothing, changing the delimiters is not supported. Standalone section and
+// comment tags do not leave empty lines.
+type Template struct {
+	nodes []node
+}
+
+// node is a part of a parsed template. Text nodes have an empty name.
+type node struct {
+	text     string
+	name     string
+	kind     byte
+	children []node
+}
+
+// node kinds, the sigils of the mustache tags
+const (
+	kindText     = 0
+	kindVariable = 'v'
+	kindRaw      = '&'
+	kindSection  = '#'
+	kindInverted = '^'
+	kindClose    = '/'
+	kindComment  = '!'
+)
+
+// ParseTemplate parses a mustache template.
+func ParseTemplate(text string) (*Template, error) {
+	nodes, _, _, err := parseNodes(text, "", 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// parseNodes parses text until the closing tag of section (or the end of text
+// if section is empty). Returns the nodes, the text after the closing tag and
+// the name of the closing tag. line is the current line number used in error
+// messages.
+func parseNodes(text string, section string, line int) ([]node, string, string, error) {
+	var nodes []node
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			if section != "" {
+				return nil, "", "", fmt.Errorf("line %d: section %q is not closed", line, section)
+			}
+			if text != "" {
+				nodes = append(nodes, node{text: text})
+			}
+			return nodes, "", "", nil
+		}
+
+		closing, kind, offset := "}}", byte(kindVariable), 2
+		switch {
+		case strings.HasPrefix(text[start:], "{{{"):
+			closing, kind, offset = "}}}", kindRaw, 3
+		case start+2 < len(text) && strings.IndexByte("&#^/!>=", text[start+2]) >= 0:
+			kind, offset = text[start+2], 3
+		}
+		end := strings.Index(text[start+offset:], closing)
+		if end < 0 {
+			return nil, "", "", fmt.Errorf("line %d: tag is not closed", line+strings.Count(text[:start], "\n"))
+		}
+		name := strings.TrimSpace(text[start+offset : start+offset+end])
+		tagEnd := start + offset + end + len(closing)
+		if kind == '=' {
+			return nil, "", "", fmt.Errorf("line %d: changing delimiters is not supported", line+strings.Count(text[:start], "\n"))
+		}
+		if name == "" && kind != kindComment {
+			return nil, "", "", fmt.Errorf("line %d: empty tag", line+strings.Count(text[:start], "\n"))
+		}
+
+		before, after := text[:start], text[tagEnd:]
+		if kind != kindVariable && kind != kindRaw {
+			before, after = trimStandalone(before, after)
+		}
+		if before != "" {
+			nodes = append(nodes, node{text: before})
+		}
+		line += strings.Count(text[:tagEnd], "\n")
+		text = after
+
+		switch kind {
+		case kindVariable, kindRaw:
+			nodes = append(nodes, node{name: name, kind: kind})
+		case kindSection, kindInverted:
+			children, rest, closed, err := parseNodes(text, name, line)
+			if err != nil {
+				return nil, "", "", err
+			}
+			if closed != name {
+				return nil, "", "", fmt.Errorf("line %d: section %q is closed by %q", line, name, closed)
+			}
+			line += strings.Count(text, "\n") - strings.Count(rest, "\n")
+			nodes = append(nodes, node{name: name, kind: kind, children: children})
+			text = rest
+		case kindClose:
+			if section == "" {
+				return nil, "", "", fmt.Errorf("line %d: unexpected closing tag {{/%s}}", line, name)
+			}
+			return nodes, text, name, nil
+		}
+	}
+}
+
+// trimStandalone removes the indentation before and the line break after a
+// tag which is the only content of its line.
+func trimStandalone(before string, after string) (string, string) {
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	if strings.TrimLeft(before[lineStart:], " \t") != "" {
+		return before, after
+	}
+	rest := strings.TrimLeft(after, " \t")
+	switch {
+	case rest == "":
+		return before[:lineStart], rest
+	case strings.HasPrefix(rest, "\r\n"):
+		return before[:lineStart], rest[2:]
+	case strings.HasPrefix(rest, "\n"):
+		return before[:lineStart], rest[1:]
+	}
+	return before, after
+}
+
+// Execute renders the template with data to w. Values are looked up in the
+// data of the enclosing sections first. Missing values render as empty
+// strings.
+func (t *Template) Execute(w io.Writer, data map[string]interface{}) error {
+	_, err := io.WriteString(w, t.Render(data))
+	return err
+}
+
+// Render renders the template with data and returns the result (see
+// Execute).
+func (t *Template) Render(data map[string]interface{}) string {
+	var b strings.Builder
+	render(&b, t.nodes, []interface{}{data})
+	return b.String()
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func render(b *strings.Builder, nodes []node, stack []interface{}) {
+	for _, n := range nodes {
+		switch n.kind {
+		case kindText:
+			b.WriteString(n.text)
+		case kindVariable, kindRaw:
+			value, ok := lookup(stack, n.name)
+			if !ok || value == nil {
+				continue
+			}
+			s := fmt.Sprint(value)
+			if n.kind == kindVariable {
+				s = htmlEscaper.Replace(s)
+			}
+			b.WriteString(s)
+		case kindSection:
+			value, _ := lookup(stack, n.name)
+			switch v := value.(type) {
+			case []map[string]interface{}:
+				for _, item := range v {
+					render(b, n.children, append(stack, item))
+				}
+			case []interface{}:
+				for _, item := range v {
+					render(b, n.children, append(stack, item))
+				}
+			case map[string]interface{}:
+				render(b, n.children, append(stack, v))
+			default:
+				if truthy(value) {
+					render(b, n.children, stack)
+				}
+			}
+		case kindInverted:
+			value, _ := lookup(stack, n.name)
+			if !truthy(value) {
+				render(b, n.children, stack)
+			}
+		}
+	}
+}
+
+// lookup returns the value of name from the innermost context defining it.
+// The name "." refers to the innermost context.
+func lookup(stack []interface{}, name string) (interface{}, bool) {
+	if name == "." {
+		return stack[len(stack)-1], true
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if m, ok := stack[i].(map[string]interface{}); ok {
+			if value, ok := m[name]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// truthy returns false for missing values, false, empty strings and empty
+// lists.
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case []map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package base16builder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateRender(t *testing.T) {
+	data := map[string]interface{}{
+		"name":  "Tomorrow & Night",
+		"dark":  true,
+		"light": false,
+		"empty": "",
+		"items": []map[string]interface{}{{"v": "a"}, {"v": "b"}},
+		"group": map[string]interface{}{"inner": "x"},
+	}
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"{{name}}", "Tomorrow &amp; Night"},
+		{"{{{name}}}", "Tomorrow & Night"},
+		{"{{& name}}", "Tomorrow & Night"},
+		{"{{ missing }}!", "!"},
+		{"{{#dark}}dark{{/dark}}{{#light}}light{{/light}}", "dark"},
+		{"{{^light}}not light{{/light}}{{^empty}}, empty{{/empty}}", "not light, empty"},
+		{"{{#items}}{{v}},{{/items}}", "a,b,"},
+		{"{{#group}}{{inner}} {{name}}{{/group}}", "x Tomorrow &amp; Night"},
+		{"a{{! comment }}b", "ab"},
+		{"a{{> partial}}b", "ab"},
+		// standalone tags do not leave empty lines
+		{"begin\n  {{#dark}}\nline\n  {{/dark}}\nend\n", "begin\nline\nend\n"},
+		{"begin\r\n{{! comment }}\r\nend", "begin\r\nend"},
+		{"x {{#dark}}y{{/dark}} z\n", "x y z\n"},
+	}
+	for _, tc := range testCases {
+		tmpl, err := ParseTemplate(tc.template)
+		if err != nil {
+			t.Errorf("%q: expected no error, got %v", tc.template, err)
+			continue
+		}
+		if got := tmpl.Render(data); got != tc.expected {
+			t.Errorf("%q: expected value=%q, got=%q", tc.template, tc.expected, got)
+		}
+	}
+
+	tmpl, _ := ParseTemplate("{{name}}")
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil || b.String() != "Tomorrow &amp; Night" {
+		t.Errorf("unexpected result %q err=%v", b.String(), err)
+	}
+}
+
+func TestParseTemplateErrorHandling(t *testing.T) {
+	for _, template := range []string{
+		"{{name",
+		"{{#section}}text",
+		"{{#a}}{{/b}}",
+		"text{{/a}}",
+		"{{}}",
+		"{{=<% %>=}}",
+	} {
+		if _, err := ParseTemplate(template); err == nil {
+			t.Errorf("%q: expected error", template)
+		}
+	}
+	_, err := ParseTemplate("line 1\nline 2 {{#open}}\n")
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error in line 2, got %v", err)
+	}
+}
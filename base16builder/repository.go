@@ -0,0 +1,167 @@
+package base16builder
+
+import (
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConfigFile is the path of the template configuration relative to the root of
+// a template repository.
+const ConfigFile = "templates/config.yaml"
+
+// RepositoryTemplate is a template of a template repository.
+type RepositoryTemplate struct {
+	// Name is the name of the template, the template is read from
+	// templates/<name>.mustache.
+	Name string
+
+	// Extension is the file extension of the outputs (e.g. ".vim").
+	Extension string
+
+	// Output is the slash separated directory of the outputs relative to the
+	// root of the output directory.
+	Output string
+
+	// Template is the parsed template.
+	Template *Template
+}
+
+// OutputPath returns the path of the output for a scheme with the given slug:
+// <output>/base16-<slug><extension>.
+func (t *RepositoryTemplate) OutputPath(slug string) string {
+	return path.Join(t.Output, "base16-"+slug+t.Extension)
+}
+
+// Repository is a base16 template repository. The templates are configured by
+// templates/config.yaml, e.g.:
+//
+//	default:
+//	  extension: .vim
+//	  output: colors
+type Repository struct {
+	Templates []*RepositoryTemplate
+}
+
+// Output is a file rendered by Repository.Build.
+type Output struct {
+	// Path is the slash separated path relative to the root of the output
+	// directory.
+	Path    string
+	Content []byte
+}
+
+// templateConfig is an entry of templates/config.yaml.
+type templateConfig struct {
+	Extension string `yaml:"extension"`
+	Output    string `yaml:"output"`
+}
+
+// LoadRepository loads the template repository in dir. See LoadRepositoryFS
+// for details.
+func LoadRepository(dir string) (*Repository, error) {
+	return LoadRepositoryFS(os.DirFS(dir))
+}
+
+// LoadRepositoryFS loads the configuration (see ConfigFile) and all templates
+// of the template repository at the root of fsys. The templates are ordered by
+// name. Returns an error if the configuration or a template cannot be read or
+// parsed.
+func LoadRepositoryFS(fsys fs.FS) (*Repository, error) {
+	data, err := fs.ReadFile(fsys, ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]templateConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %v", ConfigFile, err)
+	}
+	if len(config) == 0 {
+		return nil, fmt.Errorf("%s: no templates configured", ConfigFile)
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	repository := &Repository{}
+	for _, name := range names {
+		p := path.Join(path.Dir(ConfigFile), name+".mustache")
+		text, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+		t, err := ParseTemplate(string(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", p, err)
+		}
+		repository.Templates = append(repository.Templates, &RepositoryTemplate{
+			Name:      name,
+			Extension: config[name].Extension,
+			Output:    config[name].Output,
+			Template:  t,
+		})
+	}
+	return repository, nil
+}
+
+// Build renders all templates for scheme and returns the outputs in the order
+// of the templates. The optional argument overrides the slug of the scheme
+// (see Variables).
+func (r *Repository) Build(scheme base16.Scheme, slug ...string) []Output {
+	vars := Variables(scheme, slug...)
+	s := vars["scheme-slug"].(string)
+	outputs := make([]Output, 0, len(r.Templates))
+	for _, t := range r.Templates {
+		outputs = append(outputs, Output{
+			Path:    t.OutputPath(s),
+			Content: []byte(t.Template.Render(vars)),
+		})
+	}
+	return outputs
+}
+
+// WriteOutputs writes outputs to the directory dir. Missing directories are
+// created. Returns an error without writing any output if the path of an
+// output is absolute or resolves outside of dir (e.g. "../colors/x.vim").
+func WriteOutputs(dir string, outputs []Output) error {
+	paths := make([]string, len(outputs))
+	for i, output := range outputs {
+		p, err := localPath(output.Path)
+		if err != nil {
+			return err
+		}
+		paths[i] = filepath.Join(dir, p)
+	}
+	for i, output := range outputs {
+		p := paths[i]
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, output.Content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// localPath converts the slash separated path p to a cleaned path relative to
+// the output directory. Returns an error if p is empty, absolute or resolves
+// outside of the output directory.
+func localPath(p string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(p))
+	sep := string(filepath.Separator)
+	if p == "" || clean == "." || clean == ".." || filepath.IsAbs(clean) || filepath.VolumeName(clean) != "" ||
+		strings.HasPrefix(clean, sep) || strings.HasPrefix(clean, ".."+sep) {
+		return "", fmt.Errorf("invalid output path %q", p)
+	}
+	return clean, nil
+}
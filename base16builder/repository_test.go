@@ -0,0 +1,113 @@
+package base16builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func testRepositoryFS() fstest.MapFS {
+	return fstest.MapFS{
+		"templates/config.yaml": {Data: []byte(`
+default:
+  extension: .vim
+  output: colors
+xresources:
+  extension: .Xresources
+  output: xresources
+`)},
+		"templates/default.mustache":    {Data: []byte("let g:colors_name = \"base16-{{scheme-slug}}\"\n")},
+		"templates/xresources.mustache": {Data: []byte("*background: #{{base00-hex}}\n*foreground: #{{base05-hex}}\n")},
+	}
+}
+
+func TestLoadRepositoryFS(t *testing.T) {
+	repository, err := LoadRepositoryFS(testRepositoryFS())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(repository.Templates) != 2 || repository.Templates[0].Name != "default" || repository.Templates[1].Name != "xresources" {
+		t.Fatalf("unexpected templates %+v", repository.Templates)
+	}
+
+	outputs := repository.Build(testScheme())
+	expected := []Output{
+		{Path: "colors/base16-default-dark.vim", Content: []byte("let g:colors_name = \"base16-default-dark\"\n")},
+		{Path: "xresources/base16-default-dark.Xresources", Content: []byte("*background: #181818\n*foreground: #d8d8d8\n")},
+	}
+	if len(outputs) != len(expected) {
+		t.Fatalf("expected value=%v, got=%v", len(expected), len(outputs))
+	}
+	for i := range expected {
+		if outputs[i].Path != expected[i].Path || string(outputs[i].Content) != string(expected[i].Content) {
+			t.Errorf("expected value=%+v, got=%+v", expected[i], outputs[i])
+		}
+	}
+
+	outputs = repository.Build(testScheme(), "dd")
+	if outputs[0].Path != "colors/base16-dd.vim" {
+		t.Errorf("expected value=%v, got=%v", "colors/base16-dd.vim", outputs[0].Path)
+	}
+}
+
+func TestLoadRepositoryFSErrorHandling(t *testing.T) {
+	fsys := testRepositoryFS()
+	delete(fsys, "templates/xresources.mustache")
+	if _, err := LoadRepositoryFS(fsys); err == nil {
+		t.Errorf("expected error for missing template")
+	}
+
+	fsys = testRepositoryFS()
+	fsys["templates/default.mustache"] = &fstest.MapFile{Data: []byte("{{#open}}")}
+	if _, err := LoadRepositoryFS(fsys); err == nil {
+		t.Errorf("expected error for invalid template")
+	}
+
+	fsys = testRepositoryFS()
+	fsys["templates/config.yaml"] = &fstest.MapFile{Data: []byte("- invalid")}
+	if _, err := LoadRepositoryFS(fsys); err == nil {
+		t.Errorf("expected error for invalid config")
+	}
+	if _, err := LoadRepositoryFS(fstest.MapFS{}); err == nil {
+		t.Errorf("expected error for missing config")
+	}
+}
+
+func TestWriteOutputs(t *testing.T) {
+	dir := t.TempDir()
+	outputs := []Output{{Path: "colors/base16-default-dark.vim", Content: []byte("content")}}
+	if err := WriteOutputs(dir, outputs); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "colors", "base16-default-dark.vim"))
+	if err != nil || string(data) != "content" {
+		t.Errorf("unexpected content %q err=%v", data, err)
+	}
+}
+
+func TestWriteOutputsInvalidPath(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "out")
+	for _, p := range []string{"", ".", "..", "../base16-default-dark.vim", "colors/../../base16-default-dark.vim", "/tmp/base16-default-dark.vim"} {
+		outputs := []Output{
+			{Path: "colors/base16-default-dark.vim", Content: []byte("content")},
+			{Path: p, Content: []byte("content")},
+		}
+		if err := WriteOutputs(dir, outputs); err == nil {
+			t.Errorf("%q: expected error not nil", p)
+		}
+	}
+	// nothing is written if an output path is invalid
+	if entries, err := os.ReadDir(root); err != nil || len(entries) != 0 {
+		t.Errorf("expected no outputs, got %v err=%v", entries, err)
+	}
+
+	outputs := []Output{{Path: "colors/./../base16-default-dark.vim", Content: []byte("content")}}
+	if err := WriteOutputs(dir, outputs); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "base16-default-dark.vim")); err != nil {
+		t.Errorf("expected output in dir, got %v", err)
+	}
+}
@@ -0,0 +1,341 @@
+package base16builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// TerminalFormat is a terminal emulator configuration format.
+type TerminalFormat string
+
+// Supported terminal formats.
+const (
+	FormatAlacritty       TerminalFormat = "alacritty"
+	FormatAlacrittyYAML   TerminalFormat = "alacritty-yaml"
+	FormatKitty           TerminalFormat = "kitty"
+	FormatWezTerm         TerminalFormat = "wezterm"
+	FormatFoot            TerminalFormat = "foot"
+	FormatGhostty         TerminalFormat = "ghostty"
+	FormatXresources      TerminalFormat = "xresources"
+	FormatWindowsTerminal TerminalFormat = "windows-terminal"
+	FormatITerm2          TerminalFormat = "iterm2"
+)
+
+// TerminalFormats returns all supported terminal formats.
+func TerminalFormats() []TerminalFormat {
+	return []TerminalFormat{
+		FormatAlacritty,
+		FormatAlacrittyYAML,
+		FormatKitty,
+		FormatWezTerm,
+		FormatFoot,
+		FormatGhostty,
+		FormatXresources,
+		FormatWindowsTerminal,
+		FormatITerm2,
+	}
+}
+
+// ANSIColorNames holds the color names of the scheme used for the 16 ANSI
+// colors (standard base16 terminal mapping): black, red, green, yellow, blue,
+// magenta, cyan and white followed by their bright variants.
+var ANSIColorNames = [16]string{
+	"base00", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base05",
+	"base03", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base07",
+}
+
+// TerminalPalette holds the colors of a terminal emulator derived from a
+// scheme.
+type TerminalPalette struct {
+	Scheme string
+	Author string
+
+	Foreground          TemplateColor
+	Background          TemplateColor
+	Cursor              TemplateColor
+	CursorText          TemplateColor
+	SelectionForeground TemplateColor
+	SelectionBackground TemplateColor
+
+	// ANSI contains the 16 ANSI colors (see ANSIColorNames).
+	ANSI [16]TemplateColor
+}
+
+// NewTerminalPalette returns the terminal colors of scheme: the foreground and
+// cursor are base05, the background and cursor text base00, the selection
+// background base02 and the ANSI colors follow ANSIColorNames.
+func NewTerminalPalette(scheme base16.Scheme) *TerminalPalette {
+	color := func(name string) TemplateColor {
+		return TemplateColor{Name: name, Color: scheme.GetColor(name)}
+	}
+	p := &TerminalPalette{
+		Scheme:              scheme.Scheme(),
+		Author:              scheme.Author(),
+		Foreground:          color("base05"),
+		Background:          color("base00"),
+		Cursor:              color("base05"),
+		CursorText:          color("base00"),
+		SelectionForeground: color("base05"),
+		SelectionBackground: color("base02"),
+	}
+	for i, name := range ANSIColorNames {
+		p.ANSI[i] = color(name)
+	}
+	return p
+}
+
+// Normal returns the 8 normal ANSI colors.
+func (p *TerminalPalette) Normal() []TemplateColor {
+	return p.ANSI[:8]
+}
+
+// Bright returns the 8 bright ANSI colors.
+func (p *TerminalPalette) Bright() []TemplateColor {
+	return p.ANSI[8:]
+}
+
+// ansiNames holds the names of the 8 ANSI colors.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var terminalFuncs = template.FuncMap{
+	"ansiName":   func(i int) string { return ansiNames[i%8] },
+	"comment":    comment,
+	"tomlString": tomlString,
+}
+
+// comment returns s for use in a single line comment: line breaks and other
+// control characters are replaced by spaces, trailing spaces and backslashes
+// (line continuations in Xresources) are removed.
+func comment(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.TrimRight(s, ` \`)
+}
+
+// tomlString returns s as quoted TOML basic string. Quotes, backslashes and
+// control characters are escaped, all other characters are kept (TOML
+// documents are UTF-8 encoded).
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+var terminalTemplates = map[TerminalFormat]*template.Template{
+	FormatAlacritty: template.Must(template.New("alacritty").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - alacritty color config
+# Scheme by {{ comment .Author }}
+[colors.primary]
+background = "#{{ .Background }}"
+foreground = "#{{ .Foreground }}"
+
+[colors.cursor]
+text = "#{{ .CursorText }}"
+cursor = "#{{ .Cursor }}"
+
+[colors.selection]
+text = "#{{ .SelectionForeground }}"
+background = "#{{ .SelectionBackground }}"
+
+[colors.normal]
+{{ range $i, $c := .Normal }}{{ ansiName $i }} = "#{{ $c }}"
+{{ end }}
+[colors.bright]
+{{ range $i, $c := .Bright }}{{ ansiName $i }} = "#{{ $c }}"
+{{ end }}`)),
+
+	FormatAlacrittyYAML: template.Must(template.New("alacritty-yaml").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - alacritty color config
+# Scheme by {{ comment .Author }}
+colors:
+  primary:
+    background: '0x{{ .Background }}'
+    foreground: '0x{{ .Foreground }}'
+  cursor:
+    text: '0x{{ .CursorText }}'
+    cursor: '0x{{ .Cursor }}'
+  selection:
+    text: '0x{{ .SelectionForeground }}'
+    background: '0x{{ .SelectionBackground }}'
+  normal:
+{{ range $i, $c := .Normal }}    {{ ansiName $i }}: '0x{{ $c }}'
+{{ end }}  bright:
+{{ range $i, $c := .Bright }}    {{ ansiName $i }}: '0x{{ $c }}'
+{{ end }}`)),
+
+	FormatKitty: template.Must(template.New("kitty").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - kitty color config
+# Scheme by {{ comment .Author }}
+background #{{ .Background }}
+foreground #{{ .Foreground }}
+selection_background #{{ .SelectionBackground }}
+selection_foreground #{{ .SelectionForeground }}
+cursor #{{ .Cursor }}
+cursor_text_color #{{ .CursorText }}
+{{ range $i, $c := .ANSI }}color{{ $i }} #{{ $c }}
+{{ end }}`)),
+
+	FormatWezTerm: template.Must(template.New("wezterm").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - wezterm color scheme
+[colors]
+foreground = "#{{ .Foreground }}"
+background = "#{{ .Background }}"
+cursor_bg = "#{{ .Cursor }}"
+cursor_border = "#{{ .Cursor }}"
+cursor_fg = "#{{ .CursorText }}"
+selection_bg = "#{{ .SelectionBackground }}"
+selection_fg = "#{{ .SelectionForeground }}"
+ansi = [{{ range $i, $c := .Normal }}{{ if $i }}, {{ end }}"#{{ $c }}"{{ end }}]
+brights = [{{ range $i, $c := .Bright }}{{ if $i }}, {{ end }}"#{{ $c }}"{{ end }}]
+
+[metadata]
+name = {{ tomlString .Scheme }}
+author = {{ tomlString .Author }}
+`)),
+
+	FormatFoot: template.Must(template.New("foot").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - foot color config
+# Scheme by {{ comment .Author }}
+[cursor]
+color={{ .CursorText }} {{ .Cursor }}
+
+[colors]
+foreground={{ .Foreground }}
+background={{ .Background }}
+selection-foreground={{ .SelectionForeground }}
+selection-background={{ .SelectionBackground }}
+{{ range $i, $c := .Normal }}regular{{ $i }}={{ $c }}
+{{ end }}{{ range $i, $c := .Bright }}bright{{ $i }}={{ $c }}
+{{ end }}`)),
+
+	FormatGhostty: template.Must(template.New("ghostty").Funcs(terminalFuncs).Parse(
+		`# Base16 {{ comment .Scheme }} - ghostty color config
+# Scheme by {{ comment .Author }}
+background = {{ .Background }}
+foreground = {{ .Foreground }}
+cursor-color = {{ .Cursor }}
+cursor-text = {{ .CursorText }}
+selection-background = {{ .SelectionBackground }}
+selection-foreground = {{ .SelectionForeground }}
+{{ range $i, $c := .ANSI }}palette = {{ $i }}=#{{ $c }}
+{{ end }}`)),
+
+	FormatXresources: template.Must(template.New("xresources").Funcs(terminalFuncs).Parse(
+		`! Base16 {{ comment .Scheme }} - Xresources color config
+! Scheme by {{ comment .Author }}
+*.foreground: #{{ .Foreground }}
+*.background: #{{ .Background }}
+*.cursorColor: #{{ .Cursor }}
+{{ range $i, $c := .ANSI }}*.color{{ $i }}: #{{ $c }}
+{{ end }}`)),
+}
+
+// windowsTerminalScheme is a color scheme of the Windows Terminal settings.
+type windowsTerminalScheme struct {
+	Name                string `json:"name"`
+	Foreground          string `json:"foreground"`
+	Background          string `json:"background"`
+	CursorColor         string `json:"cursorColor"`
+	SelectionBackground string `json:"selectionBackground"`
+	Black               string `json:"black"`
+	Red                 string `json:"red"`
+	Green               string `json:"green"`
+	Yellow              string `json:"yellow"`
+	Blue                string `json:"blue"`
+	Purple              string `json:"purple"`
+	Cyan                string `json:"cyan"`
+	White               string `json:"white"`
+	BrightBlack         string `json:"brightBlack"`
+	BrightRed           string `json:"brightRed"`
+	BrightGreen         string `json:"brightGreen"`
+	BrightYellow        string `json:"brightYellow"`
+	BrightBlue          string `json:"brightBlue"`
+	BrightPurple        string `json:"brightPurple"`
+	BrightCyan          string `json:"brightCyan"`
+	BrightWhite         string `json:"brightWhite"`
+}
+
+func exportWindowsTerminal(w io.Writer, p *TerminalPalette) error {
+	hex := func(c TemplateColor) string { return "#" + c.Hex() }
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(windowsTerminalScheme{
+		Name:                "Base16 " + p.Scheme,
+		Foreground:          hex(p.Foreground),
+		Background:          hex(p.Background),
+		CursorColor:         hex(p.Cursor),
+		SelectionBackground: hex(p.SelectionBackground),
+		Black:               hex(p.ANSI[0]),
+		Red:                 hex(p.ANSI[1]),
+		Green:               hex(p.ANSI[2]),
+		Yellow:              hex(p.ANSI[3]),
+		Blue:                hex(p.ANSI[4]),
+		Purple:              hex(p.ANSI[5]),
+		Cyan:                hex(p.ANSI[6]),
+		White:               hex(p.ANSI[7]),
+		BrightBlack:         hex(p.ANSI[8]),
+		BrightRed:           hex(p.ANSI[9]),
+		BrightGreen:         hex(p.ANSI[10]),
+		BrightYellow:        hex(p.ANSI[11]),
+		BrightBlue:          hex(p.ANSI[12]),
+		BrightPurple:        hex(p.ANSI[13]),
+		BrightCyan:          hex(p.ANSI[14]),
+		BrightWhite:         hex(p.ANSI[15]),
+	})
+}
+
+// ExportTerminal writes the colors of scheme in the terminal format to w (see
+// NewTerminalPalette for the color mapping). Returns an error if the format is
+// not supported or a color of base00 - base0F is not defined.
+func ExportTerminal(w io.Writer, scheme base16.Scheme, format TerminalFormat) error {
+	for i := 0; i < base16.Base16DefaultColors; i++ {
+		if scheme.ColorAt(i) == base16.NoColor {
+			return fmt.Errorf("color %s is not defined", base16.ColorIndexName(i))
+		}
+	}
+	switch format {
+	case FormatWindowsTerminal:
+		return exportWindowsTerminal(w, NewTerminalPalette(scheme))
+	case FormatITerm2:
+		return WriteITermColors(w, scheme)
+	}
+	t, ok := terminalTemplates[format]
+	if !ok {
+		return fmt.Errorf("unsupported terminal format %q", format)
+	}
+	return t.Execute(w, NewTerminalPalette(scheme))
+}
@@ -0,0 +1,114 @@
+package base16builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"github.com/shebang-go/colorlib/base16"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestExportTerminal(t *testing.T) {
+	// names with quotes, backslashes, line breaks and non-ASCII characters
+	// must be escaped in every format
+	escaped := testScheme()
+	escaped.SetScheme(`Rosé "Pine" \ Moon`)
+	escaped.SetAuthor("Zoë \"Z\" Doe\n<zoe@example.com> \\")
+
+	testCases := map[string]base16.Scheme{
+		"":         testScheme(),
+		"-escaped": escaped,
+	}
+	for suffix, scheme := range testCases {
+		for _, format := range TerminalFormats() {
+			if suffix != "" && format == FormatITerm2 {
+				// iTerm2 presets contain no scheme metadata
+				continue
+			}
+			var b bytes.Buffer
+			if err := ExportTerminal(&b, scheme, format); err != nil {
+				t.Errorf("%s: expected no error, got %v", format, err)
+				continue
+			}
+			golden := filepath.Join("testdata", string(format)+suffix+".golden")
+			if *update {
+				if err := os.WriteFile(golden, b.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%s: expected no error, got %v", format, err)
+			}
+			if !bytes.Equal(b.Bytes(), expected) {
+				t.Errorf("%s: output does not match %s:\n%s", format, golden, b.String())
+			}
+		}
+	}
+}
+
+func TestExportWindowsTerminal(t *testing.T) {
+	var b bytes.Buffer
+	if err := ExportTerminal(&b, testScheme(), FormatWindowsTerminal); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var scheme map[string]string
+	if err := json.Unmarshal(b.Bytes(), &scheme); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if scheme["name"] != "Base16 Default Dark" || scheme["brightWhite"] != "#f8f8f8" || len(scheme) != 21 {
+		t.Errorf("unexpected scheme %v", scheme)
+	}
+
+	b.Reset()
+	escaped := testScheme()
+	escaped.SetScheme(`Rosé "Pine" <\>`)
+	if err := ExportTerminal(&b, escaped, FormatWindowsTerminal); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := json.Unmarshal(b.Bytes(), &scheme); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if scheme["name"] != `Base16 Rosé "Pine" <\>` {
+		t.Errorf("expected value=%v, got=%v", `Base16 Rosé "Pine" <\>`, scheme["name"])
+	}
+}
+
+func TestTOMLString(t *testing.T) {
+	testCases := map[string]string{
+		"Default Dark":   `"Default Dark"`,
+		`Rosé "Pine"`:    `"Rosé \"Pine\""`,
+		"a\\b\tc\nd\x01": `"a\\b\tc\nd\u0001"`,
+	}
+	for s, expected := range testCases {
+		if got := tomlString(s); got != expected {
+			t.Errorf("%q: expected value=%v, got=%v", s, expected, got)
+		}
+	}
+}
+
+func TestExportTerminalErrorHandling(t *testing.T) {
+	var b bytes.Buffer
+	if err := ExportTerminal(&b, testScheme(), "konsole"); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+	scheme := testScheme()
+	scheme.SetColor("base0F", base16.NoColor)
+	if err := ExportTerminal(&b, scheme, FormatKitty); err == nil {
+		t.Errorf("expected error for undefined color")
+	}
+}
+
+func TestNewTerminalPalette(t *testing.T) {
+	p := NewTerminalPalette(testScheme())
+	if p.Background.Hex() != "181818" || p.SelectionBackground.Hex() != "383838" || p.CursorText.Hex() != "181818" {
+		t.Errorf("unexpected palette %+v", p)
+	}
+	if len(p.Normal()) != 8 || p.Normal()[1].Name != "base08" || p.Bright()[0].Name != "base03" || p.Bright()[7].Name != "base07" {
+		t.Errorf("unexpected ANSI colors %+v", p.ANSI)
+	}
+}
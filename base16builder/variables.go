@@ -0,0 +1,87 @@
+package base16builder
+
+import (
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Slug returns the scheme identifier of a scheme name as used in file names:
+// the name in lower case with spaces replaced by "-" and all characters
+// except letters, digits, "-" and "_" removed (e.g. "Tomorrow Night" becomes
+// "tomorrow-night"). Non-ASCII letters are kept (e.g. "Rosé Pine" becomes
+// "rosé-pine").
+func Slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// Variables returns the template variables of the base16 builder
+// specification for scheme:
+//
+//	scheme-name, scheme-author       scheme metadata
+//	scheme-slug                      scheme identifier (e.g. "tomorrow-night")
+//	scheme-slug-underscored          scheme identifier (e.g. "tomorrow_night")
+//	scheme-variant                   "dark" or "light" (see base16.DetectVariant)
+//	scheme-is-dark-variant           true for dark schemes
+//	scheme-is-light-variant          true for light schemes
+//	baseXX-hex                       hex value (e.g. "7cafc2")
+//	baseXX-hex-r, -hex-g, -hex-b     hex value of a component (e.g. "7c")
+//	baseXX-hex-bgr                   hex value in blue, green, red order
+//	baseXX-rgb-r, -rgb-g, -rgb-b     component in the range 0 - 255
+//	baseXX-dec-r, -dec-g, -dec-b     component in the range 0 - 1
+//
+// The optional argument overrides the slug, by default the slug is derived
+// from the scheme name (see Slug). Undefined colors are omitted.
+func Variables(scheme base16.Scheme, slug ...string) map[string]interface{} {
+	s := Slug(scheme.Scheme())
+	if len(slug) == 1 {
+		s = slug[0]
+	}
+	variant := base16.DetectVariant(scheme)
+	vars := map[string]interface{}{
+		"scheme-name":             scheme.Scheme(),
+		"scheme-author":           scheme.Author(),
+		"scheme-slug":             s,
+		"scheme-slug-underscored": strings.ReplaceAll(s, "-", "_"),
+		"scheme-variant":          variant,
+		"scheme-is-dark-variant":  variant == base16.VariantDark,
+		"scheme-is-light-variant": variant == base16.VariantLight,
+	}
+	for _, named := range scheme.Colors() {
+		if named.Color == base16.NoColor {
+			continue
+		}
+		r, g, b := named.Color.RGB()
+		hex := fmt.Sprintf("%02x%02x%02x", r, g, b)
+		components := map[string]uint8{"r": r, "g": g, "b": b}
+		vars[named.Name+"-hex"] = hex
+		vars[named.Name+"-hex-bgr"] = hex[4:6] + hex[2:4] + hex[0:2]
+		for name, v := range components {
+			vars[named.Name+"-hex-"+name] = fmt.Sprintf("%02x", v)
+			vars[named.Name+"-rgb-"+name] = strconv.Itoa(int(v))
+			vars[named.Name+"-dec-"+name] = strconv.FormatFloat(float64(v)/255, 'f', -1, 64)
+		}
+	}
+	return vars
+}
+
+// Render parses the mustache template text and renders it with the variables
+// of scheme (see Variables). The optional argument overrides the slug.
+func Render(text string, scheme base16.Scheme, slug ...string) (string, error) {
+	t, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(Variables(scheme, slug...)), nil
+}
@@ -0,0 +1,70 @@
+package base16builder
+
+import (
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	testCases := map[string]string{
+		"Default Dark":           "default-dark",
+		" Gruvbox dark, medium ": "gruvbox-dark-medium",
+		"Atelier_Cave (Light)":   "atelier_cave-light",
+		"Rosé Pine":              "rosé-pine",
+	}
+	for name, expected := range testCases {
+		if got := Slug(name); got != expected {
+			t.Errorf("%q: expected value=%v, got=%v", name, expected, got)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	vars := Variables(testScheme())
+	expected := map[string]interface{}{
+		"scheme-name":             "Default Dark",
+		"scheme-author":           "Chris Kempson (http://chriskempson.com)",
+		"scheme-slug":             "default-dark",
+		"scheme-slug-underscored": "default_dark",
+		"scheme-variant":          "dark",
+		"scheme-is-dark-variant":  true,
+		"scheme-is-light-variant": false,
+		"base0D-hex":              "7cafc2",
+		"base0D-hex-r":            "7c",
+		"base0D-hex-g":            "af",
+		"base0D-hex-b":            "c2",
+		"base0D-hex-bgr":          "c2af7c",
+		"base0D-rgb-r":            "124",
+		"base0D-rgb-g":            "175",
+		"base0D-rgb-b":            "194",
+		"base0D-dec-r":            "0.48627450980392156",
+		"base00-dec-b":            "0.09411764705882353",
+		"base07-dec-r":            "0.9725490196078431",
+	}
+	for name, value := range expected {
+		if vars[name] != value {
+			t.Errorf("%s: expected value=%v, got=%v", name, value, vars[name])
+		}
+	}
+	// 7 scheme variables and 11 variables per color
+	if len(vars) != 7+16*11 {
+		t.Errorf("expected value=%v, got=%v", 7+16*11, len(vars))
+	}
+	if vars := Variables(testScheme(), "custom"); vars["scheme-slug"] != "custom" {
+		t.Errorf("expected value=%v, got=%v", "custom", vars["scheme-slug"])
+	}
+}
+
+func TestRender(t *testing.T) {
+	text := "\" {{scheme-name}} by {{scheme-author}}\nhi Normal guifg=#{{base05-hex}} guibg=#{{base00-hex}}\n"
+	got, err := Render(text, testScheme())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := "\" Default Dark by Chris Kempson (http://chriskempson.com)\nhi Normal guifg=#d8d8d8 guibg=#181818\n"
+	if got != expected {
+		t.Errorf("expected value=%q, got=%q", expected, got)
+	}
+	if _, err := Render("{{#open}}", testScheme()); err == nil {
+		t.Errorf("expected error for invalid template")
+	}
+}
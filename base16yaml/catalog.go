@@ -0,0 +1,230 @@
+package base16yaml
+
+import (
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"io/fs"
+	"os"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CatalogEntry describes a single scheme of a Catalog.
+type CatalogEntry struct {
+	// Path is the slash separated path of the scheme file relative to the root
+	// of the catalog.
+	Path string
+
+	// Slug is the scheme identifier derived from the file name (without
+	// extension).
+	Slug string
+
+	// Variant is either the variant defined by the scheme file or derived from
+	// the background color (base00) of the scheme.
+	Variant string
+
+	// Scheme holds the loaded scheme.
+	Scheme base16.Scheme
+}
+
+// LoadError describes a scheme file which could not be added to a catalog.
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Catalog holds a collection of schemes loaded from a directory and indexes
+// them by slug, name, author and variant. Name, author and variant lookups are
+// case insensitive. Reading a catalog is safe for concurrent use by multiple
+// goroutines, Add must not be called concurrently with any other method.
+type Catalog struct {
+	entries   []*CatalogEntry
+	bySlug    map[string]*CatalogEntry
+	byName    map[string][]*CatalogEntry
+	byAuthor  map[string][]*CatalogEntry
+	byVariant map[string][]*CatalogEntry
+	errors    []*LoadError
+}
+
+// LoadCatalog loads all scheme files (*.yaml and *.yml) found in dir and its
+// sub directories. See LoadCatalogFS for details.
+func LoadCatalog(dir string, workers ...int) (*Catalog, error) {
+	return LoadCatalogFS(os.DirFS(dir), workers...)
+}
+
+// LoadCatalogFS loads all scheme files (*.yaml and *.yml) found in fsys. Files
+// are loaded concurrently, the optional argument workers limits the number of
+// files loaded in parallel (defaults to the number of CPUs). Files which
+// cannot be loaded do not abort loading, they are reported by
+// Catalog.Errors(). An error is only returned when fsys cannot be walked.
+func LoadCatalogFS(fsys fs.FS, workers ...int) (*Catalog, error) {
+	countWorkers := runtime.NumCPU()
+	if len(workers) == 1 && workers[0] > 0 {
+		countWorkers = workers[0]
+	}
+
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isSchemeFile(p) {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*CatalogEntry, len(paths))
+	loadErrors := make([]*LoadError, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	reader := &FSReader{FS: fsys}
+
+	for i := 0; i < countWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results[job], loadErrors[job] = loadCatalogEntry(paths[job], reader)
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	catalog := NewCatalog()
+	for i, entry := range results {
+		if loadErrors[i] != nil {
+			catalog.errors = append(catalog.errors, loadErrors[i])
+			continue
+		}
+		if err := catalog.Add(entry); err != nil {
+			catalog.errors = append(catalog.errors, &LoadError{Path: entry.Path, Err: err})
+		}
+	}
+	return catalog, nil
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		bySlug:    make(map[string]*CatalogEntry),
+		byName:    make(map[string][]*CatalogEntry),
+		byAuthor:  make(map[string][]*CatalogEntry),
+		byVariant: make(map[string][]*CatalogEntry),
+	}
+}
+
+// Add adds a copy of entry to the catalog, an empty variant is derived from
+// the scheme. Returns an error when the slug of entry is already in use. Add
+// is not safe for concurrent use.
+func (c *Catalog) Add(e *CatalogEntry) error {
+	if _, ok := c.bySlug[e.Slug]; ok {
+		return fmt.Errorf("duplicate scheme slug %q", e.Slug)
+	}
+	entry := *e
+	if entry.Variant == "" {
+		entry.Variant = base16.DetectVariant(entry.Scheme)
+	}
+	c.entries = append(c.entries, &entry)
+	c.bySlug[entry.Slug] = &entry
+	name := strings.ToLower(entry.Scheme.Scheme())
+	c.byName[name] = append(c.byName[name], &entry)
+	author := strings.ToLower(entry.Scheme.Author())
+	c.byAuthor[author] = append(c.byAuthor[author], &entry)
+	variant := strings.ToLower(entry.Variant)
+	c.byVariant[variant] = append(c.byVariant[variant], &entry)
+	return nil
+}
+
+// Len returns the number of schemes in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
+
+// Entries returns all entries of the catalog in the order they were added.
+func (c *Catalog) Entries() []*CatalogEntry {
+	return append([]*CatalogEntry(nil), c.entries...)
+}
+
+// Schemes returns all schemes of the catalog in the order they were added.
+func (c *Catalog) Schemes() []base16.Scheme {
+	schemes := make([]base16.Scheme, 0, len(c.entries))
+	for _, entry := range c.entries {
+		schemes = append(schemes, entry.Scheme)
+	}
+	return schemes
+}
+
+// Slugs returns a sorted string slice of all scheme slugs.
+func (c *Catalog) Slugs() []string {
+	slugs := make([]string, 0, len(c.entries))
+	for slug := range c.bySlug {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// Lookup returns the entry of the scheme given by slug.
+func (c *Catalog) Lookup(slug string) (*CatalogEntry, bool) {
+	entry, ok := c.bySlug[slug]
+	return entry, ok
+}
+
+// ByName returns all entries with the scheme name given by name.
+func (c *Catalog) ByName(name string) []*CatalogEntry {
+	return append([]*CatalogEntry(nil), c.byName[strings.ToLower(name)]...)
+}
+
+// ByAuthor returns all entries of the author given by author.
+func (c *Catalog) ByAuthor(author string) []*CatalogEntry {
+	return append([]*CatalogEntry(nil), c.byAuthor[strings.ToLower(author)]...)
+}
+
+// ByVariant returns all entries of the variant given by variant.
+func (c *Catalog) ByVariant(variant string) []*CatalogEntry {
+	return append([]*CatalogEntry(nil), c.byVariant[strings.ToLower(variant)]...)
+}
+
+// Errors returns the errors of all files which could not be loaded.
+func (c *Catalog) Errors() []*LoadError {
+	return append([]*LoadError(nil), c.errors...)
+}
+
+func loadCatalogEntry(fname string, reader Reader) (*CatalogEntry, *LoadError) {
+	scheme, err := Load(fname, reader)
+	if err != nil {
+		return nil, &LoadError{Path: fname, Err: err}
+	}
+	return &CatalogEntry{
+		Path:    fname,
+		Slug:    strings.TrimSuffix(path.Base(fname), path.Ext(fname)),
+		Variant: scheme.Variant(),
+		Scheme:  scheme,
+	}, nil
+}
+
+func isSchemeFile(fname string) bool {
+	ext := strings.ToLower(path.Ext(fname))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,158 @@
+package base16yaml
+
+import (
+	"errors"
+	"github.com/shebang-go/colorlib/base16"
+	"os"
+	"path"
+	"reflect"
+	"runtime"
+	"testing"
+	"testing/fstest"
+)
+
+func testCatalogFS() fstest.MapFS {
+	return fstest.MapFS{
+		"default-dark.yaml":                  {Data: []byte(base16TestData["default-dark.yaml"])},
+		"extended/default-dark-extended.yml": {Data: []byte(base16TestData["default-dark-extended.yaml"])},
+		"light/default-light.yaml": {Data: []byte(`
+scheme: "Default Light"
+author: "Chris Kempson (http://chriskempson.com)"
+base00: "f8f8f8"
+base01: "e8e8e8"
+base02: "d8d8d8"
+base03: "b8b8b8"
+base04: "585858"
+base05: "383838"
+base06: "282828"
+base07: "181818"
+base08: "ab4642"
+base09: "dc9656"
+base0A: "f7ca88"
+base0B: "a1b56c"
+base0C: "86c1b9"
+base0D: "7cafc2"
+base0E: "ba8baf"
+base0F: "a16946"
+`)},
+		"duplicate/default-dark.yaml": {Data: []byte(base16TestData["default-dark.yaml"])},
+		"invalid-yaml.yaml":           {Data: []byte(base16TestData["invalid-yaml.yaml"])},
+		"missing-colors.yaml":         {Data: []byte(base16TestData["default-dark-missing-colors.yaml"])},
+		"README.md":                   {Data: []byte("not a scheme")},
+	}
+}
+
+func TestLoadCatalogFS(t *testing.T) {
+	catalog, err := LoadCatalogFS(testCatalogFS(), 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectSlugs := []string{"default-dark", "default-dark-extended", "default-light"}
+	if !reflect.DeepEqual(expectSlugs, catalog.Slugs()) {
+		t.Errorf("expected value=%v, got=%v", expectSlugs, catalog.Slugs())
+	}
+	if catalog.Len() != 3 {
+		t.Errorf("expected value=%d, got=%d", 3, catalog.Len())
+	}
+
+	entry, ok := catalog.Lookup("default-dark")
+	if !ok {
+		t.Fatalf("expected slug default-dark to be present")
+	}
+	if entry.Path != "default-dark.yaml" {
+		t.Errorf("expected value=%s, got=%s", "default-dark.yaml", entry.Path)
+	}
+	if entry.Scheme.Scheme() != "Default Dark" {
+		t.Errorf("expected value=%s, got=%s", "Default Dark", entry.Scheme.Scheme())
+	}
+
+	if got := len(catalog.ByName("default light")); got != 1 {
+		t.Errorf("expected value=%d, got=%d", 1, got)
+	}
+	if got := len(catalog.ByAuthor("Chris Kempson (http://chriskempson.com)")); got != 3 {
+		t.Errorf("expected value=%d, got=%d", 3, got)
+	}
+	if got := len(catalog.ByVariant("dark")); got != 2 {
+		t.Errorf("expected value=%d, got=%d", 2, got)
+	}
+	light := catalog.ByVariant("light")
+	if len(light) != 1 || light[0].Slug != "default-light" {
+		t.Errorf("expected default-light to be the only light scheme, got=%v", light)
+	}
+}
+
+func TestLoadCatalogFSErrors(t *testing.T) {
+	catalog, _ := LoadCatalogFS(testCatalogFS())
+
+	expectPaths := []string{"duplicate/default-dark.yaml", "invalid-yaml.yaml", "missing-colors.yaml"}
+	gotPaths := make([]string, 0, len(expectPaths))
+	for _, loadErr := range catalog.Errors() {
+		gotPaths = append(gotPaths, loadErr.Path)
+		if errors.Unwrap(loadErr) == nil {
+			t.Errorf("expected wrapped error for path=%s", loadErr.Path)
+		}
+	}
+	if !reflect.DeepEqual(expectPaths, gotPaths) {
+		t.Errorf("expected value=%v, got=%v", expectPaths, gotPaths)
+	}
+}
+
+func TestLoadCatalogVariant(t *testing.T) {
+	fsys := testCatalogFS()
+	fsys["variant.yaml"] = &fstest.MapFile{Data: []byte("variant: \"light\"\n" + base16TestData["default-dark.yaml"])}
+
+	catalog, _ := LoadCatalogFS(fsys)
+	entry, _ := catalog.Lookup("variant")
+	if entry.Variant != "light" {
+		t.Errorf("expected value=%s, got=%s", "light", entry.Variant)
+	}
+	if entry.Scheme.Variant() != "light" {
+		t.Errorf("expected value=%s, got=%s", "light", entry.Scheme.Variant())
+	}
+}
+
+func TestCatalogAdd(t *testing.T) {
+	scheme, _ := base16.NewScheme("Test", "nobody")
+	scheme.SetColor("base00", base16.NewColor("181818"))
+	entry := &CatalogEntry{Slug: "test", Scheme: scheme}
+
+	catalog := NewCatalog()
+	if err := catalog.Add(entry); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := catalog.Add(entry); err == nil {
+		t.Errorf("expected error for duplicate slug")
+	}
+	// the catalog holds a copy of the entry
+	if entry.Variant != "" {
+		t.Errorf("expected entry to be unchanged, got variant=%s", entry.Variant)
+	}
+	entry.Slug = "changed"
+	added, ok := catalog.Lookup("test")
+	if !ok || added == entry || added.Slug != "test" || added.Variant != base16.VariantDark {
+		t.Errorf("unexpected entry %+v", added)
+	}
+	if len(catalog.ByVariant(base16.VariantDark)) != 1 {
+		t.Errorf("expected entry to be indexed by variant")
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	catalog, err := LoadCatalog(path.Join(path.Dir(filename), "./testdata/data"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := catalog.Lookup("default-dark"); !ok {
+		t.Errorf("expected slug default-dark to be present")
+	}
+	if len(catalog.Errors()) != 3 {
+		t.Errorf("expected value=%d, got=%d", 3, len(catalog.Errors()))
+	}
+
+	_, err = LoadCatalog(path.Join(os.TempDir(), "base16yaml-does-not-exist"))
+	if err == nil {
+		t.Errorf("expected error not nil")
+	}
+}
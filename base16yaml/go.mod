@@ -1,8 +1,10 @@
 module github.com/shebang-go/colorlib/base16yaml
 
-go 1.14
+go 1.16
 
 require (
-	github.com/shebang-go/colorlib/base16 v0.1.6
-	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776
+	github.com/shebang-go/colorlib/base16 v0.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+replace github.com/shebang-go/colorlib/base16 => ../base16
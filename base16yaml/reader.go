@@ -3,8 +3,9 @@ package base16yaml
 import (
 	// "fmt"
 	"github.com/shebang-go/colorlib/base16"
+	"io/fs"
 	"io/ioutil"
-	// "strings"
+	"strings"
 )
 
 // Reader defines an interface for reading a file.
@@ -21,6 +22,14 @@ type FileReader struct {
 // ReadFile proxies to ioutil.ReadFile
 func (fr *FileReader) ReadFile(fname string) ([]byte, error) { return ioutil.ReadFile(fname) }
 
+// FSReader implements the Reader interface for a fs.FS file system.
+type FSReader struct {
+	FS fs.FS
+}
+
+// ReadFile proxies to fs.ReadFile
+func (fr *FSReader) ReadFile(fname string) ([]byte, error) { return fs.ReadFile(fr.FS, fname) }
+
 // Load loads a base16 yaml file given by fname and returns a Scheme interface
 // on success or an error on failure.
 func Load(fname string, readerArg ...Reader) (base16.Scheme, error) {
@@ -53,23 +62,22 @@ func Load(fname string, readerArg ...Reader) (base16.Scheme, error) {
 }
 
 func fromBase16Yaml(base16Yaml *Base16Yaml) (base16.Scheme, error) {
-	extendedMode := false
-	if len(base16Yaml.colorNames) > 16 {
-		extendedMode = true
-	}
-
 	scheme, err := base16.NewScheme(base16Yaml.Data["author"], base16Yaml.Data["scheme"], len(base16Yaml.colorNames))
 	if err != nil {
 		return nil, err
 	}
 
 	for k, v := range base16Yaml.Data {
-		if base16.ValidColorName(k, extendedMode) {
-			scheme.SetColor(k, base16.NewColor(v))
+		if strings.HasPrefix(k, "base") {
+			if err := scheme.SetColor(k, base16.NewColor(v)); err != nil {
+				return nil, err
+			}
 		} else if k == "author" {
 			scheme.SetAuthor(v)
 		} else if k == "scheme" {
 			scheme.SetScheme(v)
+		} else if k == "variant" {
+			scheme.SetVariant(v)
 		}
 	}
 	return scheme, nil
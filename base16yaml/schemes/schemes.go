@@ -0,0 +1,75 @@
+// Package schemes provides a collection of well-known base16 schemes embedded
+// into the binary. Schemes are accessed by their slug (e.g. "default-dark")
+// without touching the file system.
+package schemes
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/shebang-go/colorlib/base16"
+	"github.com/shebang-go/colorlib/base16yaml"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed data/*.yaml
+var files embed.FS
+
+// FS returns the embedded scheme files. The file names are the slugs of the
+// schemes with a ".yaml" extension.
+func FS() fs.FS {
+	data, _ := fs.Sub(files, "data")
+	return data
+}
+
+// Slugs returns a sorted string slice of all embedded scheme slugs.
+func Slugs() []string {
+	entries, _ := fs.ReadDir(files, "data")
+	slugs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		slugs = append(slugs, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// Get returns the embedded scheme given by slug. Each call returns a new
+// Scheme, modifying it does not affect other callers.
+func Get(slug string) (base16.Scheme, error) {
+	return get(FS(), slug)
+}
+
+// get loads the scheme given by slug from fsys. Returns an "unknown scheme"
+// error if the scheme file does not exist, otherwise the error of loading it.
+func get(fsys fs.FS, slug string) (base16.Scheme, error) {
+	scheme, err := base16yaml.Load(slug+".yaml", &base16yaml.FSReader{FS: fsys})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unknown scheme %q: %w", slug, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scheme %q: %w", slug, err)
+	}
+	return scheme, nil
+}
+
+// MustGet is like Get but panics if the scheme does not exist.
+func MustGet(slug string) base16.Scheme {
+	scheme, err := Get(slug)
+	if err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+// All returns all embedded schemes sorted by slug.
+func All() []base16.Scheme {
+	slugs := Slugs()
+	schemes := make([]base16.Scheme, 0, len(slugs))
+	for _, slug := range slugs {
+		schemes = append(schemes, MustGet(slug))
+	}
+	return schemes
+}
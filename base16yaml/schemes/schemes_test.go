@@ -0,0 +1,121 @@
+// +build !integration
+
+package schemes
+
+import (
+	"errors"
+	"github.com/shebang-go/colorlib/base16"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSlugs(t *testing.T) {
+	got := Slugs()
+	for _, slug := range []string{"default-dark", "default-light", "ocean", "solarized-dark", "solarized-light", "tomorrow", "tomorrow-night"} {
+		found := false
+		for _, s := range got {
+			found = found || s == slug
+		}
+		if !found {
+			t.Errorf("expected slug=%s to be present in %v", slug, got)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	scheme, err := Get("default-dark")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheme.Scheme() != "Default Dark" {
+		t.Errorf("expected value=%s, got=%s", "Default Dark", scheme.Scheme())
+	}
+	if scheme.GetColor("base0A") != base16.NewColor("f7ca88") {
+		t.Errorf("expected value=%s, got=%s", "f7ca88", scheme.GetColor("base0A").ToHexString())
+	}
+
+	// schemes must be independent copies
+	scheme.SetColor("base0A", base16.NewColor("000000"))
+	if MustGet("default-dark").GetColor("base0A") != base16.NewColor("f7ca88") {
+		t.Errorf("expected embedded scheme to be unchanged")
+	}
+
+	_, err = Get("does-not-exist")
+	if err == nil || !strings.Contains(err.Error(), "unknown scheme") {
+		t.Errorf("expected unknown scheme error, got %v", err)
+	}
+
+	// parse errors are not reported as unknown schemes
+	fsys := fstest.MapFS{"invalid.yaml": &fstest.MapFile{Data: []byte("scheme: [")}}
+	_, err = get(fsys, "invalid")
+	if err == nil || errors.Is(err, fs.ErrNotExist) || strings.Contains(err.Error(), "unknown scheme") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
+
+func TestAll(t *testing.T) {
+	schemes := All()
+	if len(schemes) != len(Slugs()) {
+		t.Fatalf("expected value=%d, got=%d", len(Slugs()), len(schemes))
+	}
+	for i, scheme := range schemes {
+		if scheme.CountColors() != base16.Base16DefaultColors {
+			t.Errorf("%s: expected value=%d, got=%d", Slugs()[i], base16.Base16DefaultColors, scheme.CountColors())
+		}
+		for _, colorname := range scheme.GetColorNames() {
+			if scheme.GetColor(colorname) == base16.NoColor {
+				t.Errorf("%s: expected color %s to be defined", Slugs()[i], colorname)
+			}
+		}
+		if base16.DetectVariant(scheme) == "" {
+			t.Errorf("%s: expected variant to be defined", Slugs()[i])
+		}
+	}
+}
+
+func TestMustGetPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	MustGet("does-not-exist")
+}
+
+func TestVariants(t *testing.T) {
+	for _, slug := range Slugs() {
+		scheme := MustGet(slug)
+		variant := scheme.Variant()
+		scheme.SetVariant("")
+		if derived := base16.DetectVariant(scheme); derived != variant {
+			t.Errorf("%s: variant metadata=%s does not match background variant=%s", slug, variant, derived)
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	for _, slug := range Slugs() {
+		scheme := MustGet(slug)
+		inverted, err := base16.Invert(scheme)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", slug, err)
+			continue
+		}
+		dark := inverted.Variant() == base16.VariantDark
+		if dark == (scheme.Variant() == base16.VariantDark) {
+			t.Errorf("%s: expected polarity to change, got variant=%s", slug, inverted.Variant())
+		}
+		for i := 1; i < 8; i++ {
+			prev, c := inverted.ColorAt(i-1), inverted.ColorAt(i)
+			if prev == c || dark && c.OKLCh().L <= prev.OKLCh().L || !dark && c.OKLCh().L >= prev.OKLCh().L {
+				t.Errorf("%s: expected strictly monotonic ramp, got %s=%s %s=%s", slug,
+					base16.ColorIndexName(i-1), prev.ToHexString(), base16.ColorIndexName(i), c.ToHexString())
+			}
+		}
+		if ratio := base16.ContrastRatio(inverted.ColorAt(5), inverted.ColorAt(0)); ratio < base16.DefaultContrast {
+			t.Errorf("%s: expected contrast >= %f, got=%f", slug, base16.DefaultContrast, ratio)
+		}
+	}
+}
@@ -51,10 +51,13 @@ func toBase16Yaml(scheme base16.Scheme) *Base16Yaml {
 		Data: make(map[string]string, scheme.CountColors()+2),
 	}
 
-	for _, key := range scheme.GetColorNames() {
-		base16Yaml.Data[key] = scheme.GetColor(key).ToHexString()
+	for _, named := range scheme.Colors() {
+		base16Yaml.Data[named.Name] = named.Color.ToHexString()
 	}
 	base16Yaml.Data["author"] = scheme.Author()
 	base16Yaml.Data["scheme"] = scheme.Scheme()
+	if scheme.Variant() != "" {
+		base16Yaml.Data["variant"] = scheme.Variant()
+	}
 	return &base16Yaml
 }